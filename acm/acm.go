@@ -0,0 +1,159 @@
+// Package acm provides a reusable Pulumi component for ACM
+// certificates with DNS validation, so CloudFront and API Gateway
+// custom domains can reference certificates created in the same stack.
+package acm
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
+	awsacm "github.com/pulumi/pulumi-aws/sdk/v6/go/aws/acm"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/route53"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// CertificateConfig configures a Certificate component.
+type CertificateConfig struct {
+	// Environment tags every resource, e.g. "dev" or "prod".
+	Environment string
+	// DomainName is the primary name the certificate covers, e.g.
+	// "example.com".
+	DomainName string
+	// SubjectAlternativeNames adds more covered names, e.g.
+	// "www.example.com" or "*.example.com".
+	SubjectAlternativeNames []string
+	// Route53ZoneId, when set, creates the DNS validation records in
+	// that zone and makes CertificateArn resolve only once the
+	// certificate is issued. Without it the caller must validate the
+	// certificate out of band.
+	Route53ZoneId string
+	// Region optionally issues the certificate in another region via a
+	// dedicated provider. Empty uses the ambient provider's region.
+	Region string
+	// ForCloudFront marks the certificate as destined for a CloudFront
+	// distribution, which only serves certificates from us-east-1;
+	// Validate then requires Region to be "us-east-1".
+	ForCloudFront bool
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// Validate checks required fields and the CloudFront region
+// requirement before any resources are created, returning every
+// problem found as one joined error.
+func (c *CertificateConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	if c.DomainName == "" {
+		errs = append(errs, fmt.Errorf("DomainName is required"))
+	}
+	if c.ForCloudFront && c.Region != "us-east-1" {
+		errs = append(errs, fmt.Errorf("CloudFront only serves certificates from us-east-1; set Region to \"us-east-1\""))
+	}
+	return errors.Join(errs...)
+}
+
+// Certificate is a DNS-validated ACM certificate.
+type Certificate struct {
+	pulumi.ResourceState
+
+	// Certificate is the underlying ACM certificate.
+	Certificate *awsacm.Certificate
+	// CertificateArn is the certificate's ARN. When validation records
+	// are created it resolves only after the certificate is issued, so
+	// dependents wait for a usable certificate.
+	CertificateArn pulumi.StringOutput
+}
+
+// NewCertificate creates an ACM certificate and, when a hosted zone is
+// supplied, its DNS validation records.
+func NewCertificate(ctx *pulumi.Context, name string, config *CertificateConfig, opts ...pulumi.ResourceOption) (*Certificate, error) {
+	if config == nil {
+		config = &CertificateConfig{}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	comp := &Certificate{}
+	if err := ctx.RegisterComponentResource("denecloud:aws:Certificate", name, comp, opts...); err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	// The certificate (and its validation waiter) may need a different
+	// region than the rest of the stack, e.g. us-east-1 for CloudFront.
+	certOpts := parentOpts
+	if config.Region != "" {
+		regional, err := aws.NewProvider(ctx, fmt.Sprintf("%s-%s", name, config.Region), &aws.ProviderArgs{
+			Region: pulumi.String(config.Region),
+		}, pulumi.Parent(comp))
+		if err != nil {
+			return nil, err
+		}
+		certOpts = append(parentOpts, pulumi.Provider(regional))
+	}
+
+	certificate, err := awsacm.NewCertificate(ctx, name, &awsacm.CertificateArgs{
+		DomainName:              pulumi.String(config.DomainName),
+		SubjectAlternativeNames: pulumi.ToStringArray(config.SubjectAlternativeNames),
+		ValidationMethod:        pulumi.String("DNS"),
+		Tags:                    tags.MergeTags(config.Environment, config.Tags),
+	}, certOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate: %w", err)
+	}
+	comp.Certificate = certificate
+	comp.CertificateArn = certificate.Arn
+
+	if config.Route53ZoneId != "" {
+		// One validation option per covered name, in request order.
+		domains := append([]string{config.DomainName}, config.SubjectAlternativeNames...)
+		fqdns := pulumi.StringArray{}
+		for i := range domains {
+			option := certificate.DomainValidationOptions.Index(pulumi.Int(i))
+			record, err := route53.NewRecord(ctx, fmt.Sprintf("%s-validation-%d", name, i), &route53.RecordArgs{
+				ZoneId:         pulumi.String(config.Route53ZoneId),
+				Name:           option.ResourceRecordName().Elem(),
+				Type:           option.ResourceRecordType().Elem(),
+				Records:        pulumi.StringArray{option.ResourceRecordValue().Elem()},
+				Ttl:            pulumi.Int(60),
+				AllowOverwrite: pulumi.Bool(true),
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			fqdns = append(fqdns, record.Fqdn)
+		}
+		validation, err := awsacm.NewCertificateValidation(ctx, name, &awsacm.CertificateValidationArgs{
+			CertificateArn:        certificate.Arn,
+			ValidationRecordFqdns: fqdns,
+		}, certOpts...)
+		if err != nil {
+			return nil, err
+		}
+		comp.CertificateArn = validation.CertificateArn
+	}
+
+	if err := ctx.RegisterResourceOutputs(comp, pulumi.Map{
+		"certificateArn": comp.CertificateArn,
+	}); err != nil {
+		return nil, err
+	}
+	return comp, nil
+}
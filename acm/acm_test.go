@@ -0,0 +1,108 @@
+package acm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestMultiSANValidationRecords(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.SubjectAlternativeNames = []string{"www.example.com", "api.example.com"}
+		config.Route53ZoneId = "Z123456"
+		_, err := NewCertificate(ctx, "cert", config)
+		return err
+	})
+
+	certificates := mocks.ByType(certificateToken)
+	if len(certificates) != 1 {
+		t.Fatalf("expected 1 certificate, got %d", len(certificates))
+	}
+	inputs := certificates[0].Inputs
+	if got := inputs["domainName"].StringValue(); got != "example.com" {
+		t.Errorf("domainName = %s, want example.com", got)
+	}
+	if got := inputs["validationMethod"].StringValue(); got != "DNS" {
+		t.Errorf("validationMethod = %s, want DNS", got)
+	}
+
+	records := mocks.ByType(recordToken)
+	if len(records) != 3 {
+		t.Fatalf("expected one validation record per covered name, got %d", len(records))
+	}
+	seen := map[string]bool{}
+	for _, record := range records {
+		if got := record.Inputs["zoneId"].StringValue(); got != "Z123456" {
+			t.Errorf("record zoneId = %s, want Z123456", got)
+		}
+		if got := record.Inputs["type"].StringValue(); got != "CNAME" {
+			t.Errorf("record type = %s, want CNAME", got)
+		}
+		seen[record.Inputs["name"].StringValue()] = true
+	}
+	for _, domain := range []string{"example.com", "www.example.com", "api.example.com"} {
+		if !seen["_validation."+domain] {
+			t.Errorf("no validation record for %s, got %v", domain, seen)
+		}
+	}
+
+	if validations := mocks.ByType(validationToken); len(validations) != 1 {
+		t.Errorf("expected 1 certificate validation, got %d", len(validations))
+	}
+}
+
+func TestNoValidationWithoutZone(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		cert, err := NewCertificate(ctx, "cert", testConfig())
+		if err != nil {
+			return err
+		}
+		cert.CertificateArn.ApplyT(func(arn string) int {
+			if !strings.HasPrefix(arn, "arn:") {
+				t.Errorf("certificateArn = %s, want the certificate's ARN", arn)
+			}
+			return 0
+		})
+		return nil
+	})
+
+	if records := mocks.ByType(recordToken); len(records) != 0 {
+		t.Errorf("expected no validation records, got %d", len(records))
+	}
+	if validations := mocks.ByType(validationToken); len(validations) != 0 {
+		t.Errorf("expected no certificate validation, got %d", len(validations))
+	}
+}
+
+func TestRegionCreatesDedicatedProvider(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Region = "us-east-1"
+		config.ForCloudFront = true
+		_, err := NewCertificate(ctx, "cert", config)
+		return err
+	})
+
+	providers := mocks.ByType("pulumi:providers:aws")
+	if len(providers) != 1 {
+		t.Fatalf("expected 1 dedicated provider, got %d", len(providers))
+	}
+	if got := providers[0].Inputs["region"].StringValue(); got != "us-east-1" {
+		t.Errorf("provider region = %s, want us-east-1", got)
+	}
+}
+
+func TestCloudFrontCertificateRequiresUsEast1(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ForCloudFront = true
+		config.Region = "eu-west-1"
+		_, err := NewCertificate(ctx, "cert", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a CloudFront certificate outside us-east-1 to be rejected")
+	}
+}
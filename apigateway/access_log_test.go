@@ -0,0 +1,196 @@
+package apigateway
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	logGroupToken = "aws:cloudwatch/logGroup:LogGroup"
+	stageToken    = "aws:apigateway/stage:Stage"
+)
+
+func TestAccessLogAutoCreatesLogGroup(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			AccessLog: &AccessLogConfig{Enabled: true, RetentionDays: 14},
+		})
+		return err
+	})
+
+	logGroups := mocks.ByType(logGroupToken)
+	if len(logGroups) != 1 {
+		t.Fatalf("expected 1 log group, got %d", len(logGroups))
+	}
+	if got := logGroups[0].Inputs["retentionInDays"].NumberValue(); got != 14 {
+		t.Errorf("retentionInDays = %v, want 14", got)
+	}
+
+	stages := mocks.ByType(stageToken)
+	if len(stages) != 1 {
+		t.Fatalf("expected 1 stage, got %d", len(stages))
+	}
+	settings := stages[0].Inputs["accessLogSettings"]
+	if settings.IsNull() {
+		t.Fatal("stage has no accessLogSettings")
+	}
+	if format := settings.ObjectValue()["format"].StringValue(); format != defaultAccessLogFormat {
+		t.Errorf("unexpected default format: %s", format)
+	}
+}
+
+func TestAccessLogBringYourOwnArn(t *testing.T) {
+	const arn = "arn:aws:logs:us-east-1:123456789012:log-group:existing"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			AccessLog: &AccessLogConfig{Enabled: true, DestinationArn: arn, Format: "$context.requestId"},
+		})
+		return err
+	})
+
+	if logGroups := mocks.ByType(logGroupToken); len(logGroups) != 0 {
+		t.Fatalf("expected no log groups, got %d", len(logGroups))
+	}
+	settings := mocks.ByType(stageToken)[0].Inputs["accessLogSettings"].ObjectValue()
+	if got := settings["destinationArn"].StringValue(); got != arn {
+		t.Errorf("destinationArn = %s, want %s", got, arn)
+	}
+	if got := settings["format"].StringValue(); got != "$context.requestId" {
+		t.Errorf("format = %s, want custom format", got)
+	}
+}
+
+func TestAccessLogJSONPresetFields(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			AccessLog: &AccessLogConfig{Enabled: true, LogFormat: LogFormatJSON},
+		})
+		return err
+	})
+
+	format := mocks.ByType(stageToken)[0].Inputs["accessLogSettings"].ObjectValue()["format"].StringValue()
+	for _, field := range []string{
+		"$context.requestId",
+		"$context.identity.sourceIp",
+		"$context.status",
+		"$context.integrationLatency",
+		"$context.error.message",
+	} {
+		if !strings.Contains(format, field) {
+			t.Errorf("JSON preset missing %s: %s", field, format)
+		}
+	}
+	if !json.Valid([]byte(format)) {
+		t.Errorf("JSON preset is not valid JSON: %s", format)
+	}
+}
+
+func TestAccessLogCLFPreset(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			AccessLog: &AccessLogConfig{Enabled: true, LogFormat: LogFormatCLF},
+		})
+		return err
+	})
+
+	format := mocks.ByType(stageToken)[0].Inputs["accessLogSettings"].ObjectValue()["format"].StringValue()
+	if format != clfAccessLogFormat {
+		t.Errorf("unexpected CLF format: %s", format)
+	}
+}
+
+func TestAccessLogCustomFormat(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			AccessLog: &AccessLogConfig{Enabled: true, LogFormat: LogFormatCustom, Format: "$context.requestId $context.status"},
+		})
+		return err
+	})
+
+	format := mocks.ByType(stageToken)[0].Inputs["accessLogSettings"].ObjectValue()["format"].StringValue()
+	if format != "$context.requestId $context.status" {
+		t.Errorf("format = %s, want the custom string", format)
+	}
+}
+
+func TestAccessLogCustomRequiresFormat(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			AccessLog: &AccessLogConfig{Enabled: true, LogFormat: LogFormatCustom},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected LogFormat Custom without a Format to be rejected")
+	}
+}
+
+func TestXRayTracingFlagPropagates(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:       "dev",
+			EnableXRayTracing: true,
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	stage := mocks.ByType(stageToken)[0]
+	if !stage.Inputs["xrayTracingEnabled"].BoolValue() {
+		t.Error("xrayTracingEnabled not set on stage")
+	}
+}
+
+func TestXRayTracingDefaultsOff(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	stage := mocks.ByType(stageToken)[0]
+	if v, ok := stage.Inputs["xrayTracingEnabled"]; ok && !v.IsNull() && v.BoolValue() {
+		t.Error("xrayTracingEnabled should default to off")
+	}
+}
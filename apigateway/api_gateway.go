@@ -0,0 +1,1680 @@
+// Package apigateway provides a reusable Pulumi component for building
+// AWS API Gateway REST APIs backed by Lambda functions.
+package apigateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/apigateway"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/apigatewayv2"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/cloudwatch"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/lambda"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/route53"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/wafv2"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// QuotaConfig limits the number of requests an API key can make over a
+// period. Period must be one of DAY, WEEK or MONTH.
+type QuotaConfig struct {
+	Limit  int
+	Period string
+}
+
+// ApiKeyConfig creates one named API key for a multi-tenant API.
+type ApiKeyConfig struct {
+	// Name identifies the key and is referenced from
+	// UsagePlanConfig.KeyNames.
+	Name string
+	// Disabled deactivates the key without deleting it.
+	Disabled bool
+	// Value optionally pins the key value; empty lets AWS generate one.
+	Value string
+}
+
+// UsagePlanConfig creates one named usage plan covering every stage,
+// e.g. a "free" and a "premium" tier with different quotas.
+type UsagePlanConfig struct {
+	// Name identifies the plan.
+	Name string
+	// Quota limits the total number of requests over a period.
+	Quota *QuotaConfig
+	// Throttle caps the request rate for the plan.
+	Throttle *ThrottleConfig
+	// KeyNames associates ApiKeys entries with this plan.
+	KeyNames []string
+}
+
+// StageConfig describes one deployment stage of the API. All stages
+// share the same deployment, so teams can promote identical code
+// through dev/staging/prod with different operational settings.
+type StageConfig struct {
+	// Name is the stage name, e.g. "dev" or "prod".
+	Name string
+	// Variables sets the stage variables, commonly referenced from
+	// integration URIs as ${stageVariables.x} to parameterize backends
+	// or Lambda aliases per stage.
+	Variables map[string]string
+	// Throttle overrides the config-level default throttling limits
+	// for this stage.
+	Throttle *ThrottleConfig
+	// AccessLog optionally enables access logging for this stage.
+	AccessLog *AccessLogConfig
+	// EnableXRayTracing turns on X-Ray tracing for this stage.
+	EnableXRayTracing bool
+}
+
+// GatewayResponseConfig customizes one of API Gateway's built-in
+// response types, e.g. DEFAULT_4XX, DEFAULT_5XX or UNAUTHORIZED.
+type GatewayResponseConfig struct {
+	// ResponseType is the gateway response type to override.
+	ResponseType string
+	// StatusCode optionally overrides the returned status code.
+	StatusCode string
+	// ResponseTemplates maps content types to response body templates.
+	ResponseTemplates map[string]string
+}
+
+// ThrottleConfig caps request rates. A zero value leaves the AWS
+// account default in place.
+type ThrottleConfig struct {
+	// BurstLimit is the maximum concurrent request burst.
+	BurstLimit int
+	// RateLimit is the steady-state requests per second.
+	RateLimit float64
+}
+
+// Access log format presets for AccessLogConfig.LogFormat.
+const (
+	// LogFormatJSON is the default structured JSON preset covering
+	// request id, caller identity, status, latencies and error fields.
+	LogFormatJSON = "JSON"
+	// LogFormatCLF emits Common Log Format lines.
+	LogFormatCLF = "CLF"
+	// LogFormatCustom uses the raw Format string as-is.
+	LogFormatCustom = "Custom"
+)
+
+// defaultAccessLogFormat is the JSON access log format used when the
+// caller does not supply one.
+const defaultAccessLogFormat = `{"requestId":"$context.requestId","ip":"$context.identity.sourceIp","caller":"$context.identity.caller","user":"$context.identity.user","requestTime":"$context.requestTime","httpMethod":"$context.httpMethod","resourcePath":"$context.resourcePath","status":"$context.status","integrationLatency":"$context.integrationLatency","responseLatency":"$context.responseLatency","errorMessage":"$context.error.message","errorType":"$context.error.responseType"}`
+
+// clfAccessLogFormat is the Common Log Format preset.
+const clfAccessLogFormat = `$context.identity.sourceIp $context.identity.caller $context.identity.user [$context.requestTime] "$context.httpMethod $context.resourcePath $context.protocol" $context.status $context.responseLength $context.requestId`
+
+// AccessLogConfig enables CloudWatch access logging on the stage.
+type AccessLogConfig struct {
+	// Enabled turns access logging on.
+	Enabled bool
+	// DestinationArn is an existing log group ARN. When empty, a log
+	// group is created for the stage.
+	DestinationArn string
+	// LogFormat selects a format preset: LogFormatJSON (the default),
+	// LogFormatCLF or LogFormatCustom.
+	LogFormat string
+	// Format is the raw access log format for LogFormatCustom. For
+	// backwards compatibility it is also honored when LogFormat is
+	// unset.
+	Format string
+	// RetentionDays sets retention on the auto-created log group.
+	// Defaults to 30. Ignored when DestinationArn is supplied.
+	RetentionDays int
+}
+
+// validate checks the LogFormat/Format combination.
+func (alc *AccessLogConfig) validate() error {
+	switch alc.LogFormat {
+	case "":
+	case LogFormatJSON, LogFormatCLF:
+		if alc.Format != "" {
+			return fmt.Errorf("AccessLog: Format is only used with LogFormat Custom")
+		}
+	case LogFormatCustom:
+		if alc.Format == "" {
+			return fmt.Errorf("AccessLog: LogFormat Custom requires a Format string")
+		}
+	default:
+		return fmt.Errorf("AccessLog: invalid LogFormat %q: must be JSON, CLF or Custom", alc.LogFormat)
+	}
+	return nil
+}
+
+// resolveFormat returns the effective access log format string.
+func (alc *AccessLogConfig) resolveFormat() string {
+	switch alc.LogFormat {
+	case LogFormatCLF:
+		return clfAccessLogFormat
+	case LogFormatCustom:
+		return alc.Format
+	default:
+		if alc.Format != "" {
+			return alc.Format
+		}
+		return defaultAccessLogFormat
+	}
+}
+
+// LambdaAuthorizerConfig configures a Lambda authorizer for the API.
+// Endpoints opt in with Authorization: "CUSTOM".
+type LambdaAuthorizerConfig struct {
+	// Function validates incoming requests.
+	Function *lambda.Function
+	// AuthorizerType is TOKEN (default) or REQUEST.
+	AuthorizerType string
+	// IdentitySources lists the request parts carrying identity, e.g.
+	// "method.request.header.Authorization" or
+	// "method.request.querystring.token". Defaults to the Authorization
+	// header for TOKEN authorizers; REQUEST authorizers must set at
+	// least one.
+	IdentitySources []string
+	// ResultTTLSeconds caches authorizer results for this long.
+	ResultTTLSeconds int
+}
+
+// CognitoAuthorizerConfig configures a Cognito user pool authorizer.
+// Endpoints opt in with Authorization: "COGNITO_USER_POOLS".
+type CognitoAuthorizerConfig struct {
+	// ProviderARNs lists the Cognito user pool ARNs to validate against.
+	ProviderARNs []string
+}
+
+// MutualTLSConfig requires clients to present a certificate signed by
+// a CA in the truststore. Mutual TLS is only supported on REGIONAL
+// custom domains.
+type MutualTLSConfig struct {
+	// TruststoreUri is the S3 URI of the truststore bundle, e.g.
+	// "s3://my-bucket/truststore.pem".
+	TruststoreUri string
+	// TruststoreVersion pins a specific S3 object version. Requires
+	// versioning on the truststore bucket.
+	TruststoreVersion string
+}
+
+// CustomDomainConfig attaches a custom domain name to the deployed
+// stage.
+type CustomDomainConfig struct {
+	// DomainName is the fully qualified domain, e.g. "api.example.com".
+	DomainName string
+	// CertificateArn is the ACM certificate covering DomainName.
+	CertificateArn string
+	// Route53ZoneId enables automatic DNS setup. When non-empty, alias
+	// A and AAAA records pointing at the API Gateway domain are created
+	// in that hosted zone. Optional: if you want automatic DNS setup.
+	Route53ZoneId string
+	// RegionalCertificateArn is the ACM certificate for REGIONAL
+	// domains, which must live in the API's region. Falls back to
+	// CertificateArn when unset.
+	RegionalCertificateArn string
+	// MutualTLS optionally requires client certificate authentication
+	// on the domain. Requires the REGIONAL endpoint type.
+	MutualTLS *MutualTLSConfig
+	// BasePath maps the API under this path segment on the domain.
+	// Empty maps the API at the domain root.
+	BasePath string
+}
+
+// CORSConfig customizes the CORS headers returned by the generated
+// OPTIONS preflight responses. Empty fields fall back to permissive
+// defaults.
+type CORSConfig struct {
+	// AllowOrigins lists the allowed origins. Defaults to ["*"].
+	AllowOrigins []string
+	// AllowHeaders lists the allowed request headers.
+	AllowHeaders []string
+	// AllowMethods lists the allowed HTTP methods.
+	AllowMethods []string
+	// ExposeHeaders lists response headers browsers may read.
+	ExposeHeaders []string
+	// MaxAgeSeconds caches the preflight response for this long.
+	MaxAgeSeconds int
+	// AllowCredentials permits credentialed requests. Requires a
+	// concrete AllowOrigins entry; AWS rejects credentials with "*".
+	AllowCredentials bool
+}
+
+// allowOrigins renders the Access-Control-Allow-Origin value.
+func (c *CORSConfig) allowOrigins() string {
+	if c == nil || len(c.AllowOrigins) == 0 {
+		return "*"
+	}
+	return strings.Join(c.AllowOrigins, ",")
+}
+
+// allowHeaders renders the Access-Control-Allow-Headers value.
+func (c *CORSConfig) allowHeaders() string {
+	if c == nil || len(c.AllowHeaders) == 0 {
+		return "Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token"
+	}
+	return strings.Join(c.AllowHeaders, ",")
+}
+
+// allowMethods renders the Access-Control-Allow-Methods value.
+func (c *CORSConfig) allowMethods() string {
+	if c == nil || len(c.AllowMethods) == 0 {
+		return "GET,POST,PUT,PATCH,DELETE,OPTIONS"
+	}
+	return strings.Join(c.AllowMethods, ",")
+}
+
+// VpcLinkConfig creates a VPC link so integrations can reach private
+// network load balancers. AWS currently supports one target per link.
+type VpcLinkConfig struct {
+	// Name identifies the link; endpoints reference it via VpcLinkName.
+	Name string
+	// TargetArn is the ARN of the network load balancer behind the
+	// link.
+	TargetArn string
+}
+
+// EndpointConfig describes a single routable endpoint on the API.
+type EndpointConfig struct {
+	// Path is the request path, e.g. "/users/{id}/profile". Parameter
+	// segments ({id}) and greedy proxies ({proxy+}) are passed through
+	// to API Gateway verbatim.
+	Path string
+	// Method is the HTTP method (GET, POST, ...). Use "ANY" to match
+	// every verb, typically combined with a {proxy+} path.
+	Method string
+	// LambdaFunction handles requests via an AWS_PROXY integration.
+	// Required for AWS_PROXY endpoints, forbidden otherwise.
+	LambdaFunction *lambda.Function
+	// IntegrationType is AWS_PROXY (default), HTTP_PROXY for plain
+	// HTTP backends, or AWS for direct service integrations.
+	IntegrationType string
+	// TargetURI is the backend URI for HTTP_PROXY and AWS
+	// integrations, e.g. "https://backend.example.com/{proxy}" or an
+	// "arn:aws:apigateway:..." service URI.
+	TargetURI string
+	// IntegrationRequestTemplates maps content types to VTL mapping
+	// templates for AWS integrations.
+	IntegrationRequestTemplates map[string]string
+	// CredentialsArn is the IAM role assumed by API Gateway for AWS
+	// integrations.
+	CredentialsArn string
+	// TimeoutMilliseconds overrides the integration timeout. Must be
+	// between 50 and 29000; zero keeps the AWS default of 29 seconds.
+	TimeoutMilliseconds int
+	// ConnectionType is INTERNET (default) or VPC_LINK for private
+	// integrations reached through a VPC link.
+	ConnectionType string
+	// VpcLinkName references a VpcLinks entry by name. Requires
+	// ConnectionType VPC_LINK and an HTTP_PROXY integration.
+	VpcLinkName string
+	// Authorization is the method authorization type. Defaults to NONE.
+	Authorization string
+	// APIKeyRequired requires callers to present a valid API key.
+	APIKeyRequired bool
+	// RequestParameters maps parameter locations (e.g.
+	// "method.request.querystring.limit") to whether they are required.
+	RequestParameters map[string]bool
+	// RequestModels maps content types to existing model names for the
+	// request body.
+	RequestModels map[string]string
+	// RequestSchemas maps content types to JSON schema documents. A
+	// Model resource is created for each entry and attached to the
+	// method alongside RequestModels.
+	RequestSchemas map[string]string
+	// ValidateRequestBody makes API Gateway validate the request body
+	// against the attached models before invoking the integration.
+	ValidateRequestBody bool
+	// ValidateRequestParameters makes API Gateway validate required
+	// request parameters before invoking the integration.
+	ValidateRequestParameters bool
+	// ThrottleBurstLimit overrides the stage default burst limit for
+	// this method. Zero means no override.
+	ThrottleBurstLimit int
+	// ThrottleRateLimit overrides the stage default rate limit for this
+	// method. Zero means no override.
+	ThrottleRateLimit float64
+}
+
+// APIGatewayConfig configures an APIGateway component.
+type APIGatewayConfig struct {
+	// Environment tags every created resource (e.g. "dev", "prod").
+	Environment string
+	// APIType selects between a REST API (default) and the cheaper
+	// HTTP API built on API Gateway v2.
+	APIType string
+	// EndpointType is EDGE (default), REGIONAL or PRIVATE. REST mode
+	// only.
+	EndpointType string
+	// VPCEndpointIds attaches the API to these VPC endpoints. Only
+	// valid with the PRIVATE endpoint type.
+	VPCEndpointIds []string
+	// Description is the REST API description.
+	Description string
+	// StageName is the deployment stage, shorthand for a single-entry
+	// Stages list. Defaults to the environment name.
+	StageName string
+	// Stages creates several stages sharing one deployment. When set,
+	// StageName is ignored.
+	Stages []StageConfig
+	// StageVariables sets the stage variables for the shorthand
+	// single-stage form. Ignored when Stages is set.
+	StageVariables map[string]string
+	// Endpoints lists the routes exposed by the API.
+	Endpoints []EndpointConfig
+	// VpcLinks creates VPC links for private integrations. Endpoints
+	// reference them by name with ConnectionType VPC_LINK.
+	VpcLinks []VpcLinkConfig
+	// OpenAPIBody creates the REST API from an OpenAPI/Swagger
+	// document instead of the Endpoints list. Occurrences of
+	// ${lambda:<name>} in the document are replaced with the invoke
+	// ARN of the matching OpenAPIFunctions entry. Mutually exclusive
+	// with Endpoints.
+	OpenAPIBody string
+	// OpenAPIBodyFile reads the OpenAPI document from a file. Mutually
+	// exclusive with OpenAPIBody.
+	OpenAPIBodyFile string
+	// OpenAPIFunctions maps ${lambda:<name>} placeholders in the
+	// OpenAPI document to the Lambda functions backing them. Invoke
+	// permissions are created for each entry.
+	OpenAPIFunctions map[string]*lambda.Function
+	// EnableCORS adds an OPTIONS mock integration to every resource that
+	// has at least one method, responding with permissive wildcard
+	// headers unless CORS narrows them.
+	EnableCORS bool
+	// CORS customizes the preflight response headers. Setting it
+	// implies EnableCORS.
+	CORS *CORSConfig
+	// LambdaAuthorizer optionally creates a Lambda authorizer used by
+	// endpoints with Authorization "CUSTOM".
+	LambdaAuthorizer *LambdaAuthorizerConfig
+	// CognitoAuthorizer optionally creates a Cognito user pool
+	// authorizer used by endpoints with Authorization
+	// "COGNITO_USER_POOLS". Both authorizers can coexist.
+	CognitoAuthorizer *CognitoAuthorizerConfig
+	// RequireAPIKey creates an API key and usage plan bound to the stage.
+	// The key and plan are also created when any endpoint sets
+	// APIKeyRequired, so keyed methods never reference a missing key.
+	RequireAPIKey bool
+	// ApiKeys creates additional named API keys, associated with usage
+	// plans through UsagePlans.
+	ApiKeys []ApiKeyConfig
+	// UsagePlans creates named usage plans covering every stage, each
+	// with its own quota, throttling and key associations.
+	UsagePlans []UsagePlanConfig
+	// Quota optionally limits API key usage. Only used with RequireAPIKey.
+	Quota *QuotaConfig
+	// Throttle sets the stage-wide default throttling limits, applied
+	// to every method and to the usage plan when one is created.
+	Throttle *ThrottleConfig
+	// GatewayResponses overrides built-in gateway responses. When CORS
+	// is enabled and this is empty, the DEFAULT_4XX and DEFAULT_5XX
+	// responses are overridden so rejected requests still carry CORS
+	// headers.
+	GatewayResponses []GatewayResponseConfig
+	// EnableXRayTracing turns on X-Ray tracing for the stage, giving
+	// end-to-end traces from the gateway through Lambda.
+	EnableXRayTracing bool
+	// AccessLog optionally enables access logging on the stage.
+	AccessLog *AccessLogConfig
+	// CustomDomain optionally attaches a custom domain to the stage.
+	CustomDomain *CustomDomainConfig
+	// CustomDomains attaches several custom domains, each with its own
+	// certificate and optional base path. CustomDomain, when also set,
+	// is treated as the first entry.
+	CustomDomains []CustomDomainConfig
+	// WebAclArn associates a WAFv2 web ACL (REGIONAL scope) with every
+	// deployed stage, e.g. the ACLArn output of a waf.WebACL. REST
+	// only; WAF does not support HTTP APIs.
+	WebAclArn pulumi.StringInput
+	// ImportID adopts an existing REST API instead of creating a new
+	// one, via pulumi.Import. Child resources (resources, methods, deployments) are
+	// still created and must not already exist.
+	ImportID string
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// APIGateway is a REST API with Lambda proxy integrations, a deployment
+// stage and optional API key, usage plan and custom domain.
+type APIGateway struct {
+	pulumi.ResourceState
+
+	// API is the underlying REST API resource. Nil in HTTP mode.
+	API *apigateway.RestApi
+	// HTTPAPI is the underlying v2 API resource. Nil in REST mode.
+	HTTPAPI *apigatewayv2.Api
+	// Stage is the first deployed stage. Nil in HTTP mode.
+	Stage *apigateway.Stage
+	// StageURLs maps each stage name to its invoke URL.
+	StageURLs map[string]pulumi.StringOutput
+	// BaseURL is the invoke URL of the first stage.
+	BaseURL pulumi.StringOutput
+	// APIKey is set when RequireAPIKey is enabled.
+	APIKey *apigateway.ApiKey
+	// APIKeyIDs maps each ApiKeys entry name to the created key id.
+	APIKeyIDs map[string]pulumi.StringOutput
+	// APIKeyValues maps each ApiKeys entry name to the key value.
+	APIKeyValues map[string]pulumi.StringOutput
+	// DeploymentHash is the content hash used to trigger redeployments.
+	// It only changes when the API definition itself changes.
+	DeploymentHash pulumi.StringOutput
+	// AccessLogGroupName is the name of the auto-created access log
+	// group. Unset when access logging is disabled or a destination ARN
+	// was supplied.
+	AccessLogGroupName pulumi.StringOutput
+	// DomainFQDN is the fully qualified name of the Route53 alias
+	// record for the first custom domain. Unset unless a custom domain
+	// with Route53ZoneId is given.
+	DomainFQDN pulumi.StringOutput
+	// DomainURLs maps each custom domain to its invoke URL, including
+	// the base path when one is mapped.
+	DomainURLs map[string]pulumi.StringOutput
+}
+
+// Validate checks required fields and enum values before any resources
+// are created, returning every problem found as one joined error.
+func (c *APIGatewayConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	if c.Quota != nil {
+		switch c.Quota.Period {
+		case "DAY", "WEEK", "MONTH":
+		default:
+			errs = append(errs, fmt.Errorf("invalid QuotaConfig.Period %q: must be DAY, WEEK or MONTH", c.Quota.Period))
+		}
+	}
+	if c.AccessLog != nil {
+		if err := c.AccessLog.validate(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for i, sc := range c.Stages {
+		if sc.AccessLog != nil {
+			if err := sc.AccessLog.validate(); err != nil {
+				errs = append(errs, fmt.Errorf("Stages[%d]: %w", i, err))
+			}
+		}
+	}
+	if c.CustomDomain != nil && c.CustomDomain.MutualTLS != nil && c.CustomDomain.MutualTLS.TruststoreUri == "" {
+		errs = append(errs, fmt.Errorf("MutualTLS requires a TruststoreUri"))
+	}
+	for i, cd := range c.CustomDomains {
+		if cd.DomainName == "" {
+			errs = append(errs, fmt.Errorf("CustomDomains[%d]: DomainName is required", i))
+		}
+		if cd.MutualTLS != nil && cd.MutualTLS.TruststoreUri == "" {
+			errs = append(errs, fmt.Errorf("CustomDomains[%d]: MutualTLS requires a TruststoreUri", i))
+		}
+	}
+	keyNames := map[string]bool{}
+	for i, kc := range c.ApiKeys {
+		if kc.Name == "" {
+			errs = append(errs, fmt.Errorf("ApiKeys[%d]: Name is required", i))
+		}
+		if keyNames[kc.Name] {
+			errs = append(errs, fmt.Errorf("ApiKeys[%d]: duplicate key name %q", i, kc.Name))
+		}
+		keyNames[kc.Name] = true
+	}
+	for i, pc := range c.UsagePlans {
+		if pc.Name == "" {
+			errs = append(errs, fmt.Errorf("UsagePlans[%d]: Name is required", i))
+		}
+		if pc.Quota != nil {
+			switch pc.Quota.Period {
+			case "DAY", "WEEK", "MONTH":
+			default:
+				errs = append(errs, fmt.Errorf("UsagePlans[%d]: invalid Quota.Period %q: must be DAY, WEEK or MONTH", i, pc.Quota.Period))
+			}
+		}
+		for _, keyName := range pc.KeyNames {
+			if !keyNames[keyName] {
+				errs = append(errs, fmt.Errorf("UsagePlans[%d]: KeyName %q does not match any ApiKeys entry", i, keyName))
+			}
+		}
+	}
+	if c.OpenAPIBody != "" && c.OpenAPIBodyFile != "" {
+		errs = append(errs, fmt.Errorf("OpenAPIBody and OpenAPIBodyFile are mutually exclusive"))
+	}
+	if (c.OpenAPIBody != "" || c.OpenAPIBodyFile != "") && len(c.Endpoints) > 0 {
+		errs = append(errs, fmt.Errorf("OpenAPI import is mutually exclusive with Endpoints"))
+	}
+	linkNames := map[string]bool{}
+	for i, link := range c.VpcLinks {
+		if link.Name == "" {
+			errs = append(errs, fmt.Errorf("VpcLinks[%d]: Name is required", i))
+		}
+		if link.TargetArn == "" {
+			errs = append(errs, fmt.Errorf("VpcLinks[%d]: TargetArn is required", i))
+		}
+		linkNames[link.Name] = true
+	}
+	for _, ep := range c.Endpoints {
+		switch ep.ConnectionType {
+		case "", "INTERNET":
+			if ep.VpcLinkName != "" {
+				errs = append(errs, fmt.Errorf("endpoint %s %s: VpcLinkName requires ConnectionType VPC_LINK", ep.Method, ep.Path))
+			}
+		case "VPC_LINK":
+			if ep.VpcLinkName == "" {
+				errs = append(errs, fmt.Errorf("endpoint %s %s: ConnectionType VPC_LINK requires a VpcLinkName", ep.Method, ep.Path))
+			} else if !linkNames[ep.VpcLinkName] {
+				errs = append(errs, fmt.Errorf("endpoint %s %s: VpcLinkName %q does not match any VpcLinks entry", ep.Method, ep.Path, ep.VpcLinkName))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("endpoint %s %s: invalid ConnectionType %q: must be INTERNET or VPC_LINK", ep.Method, ep.Path, ep.ConnectionType))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewAPIGateway creates a REST API from the given config. Intermediate
+// path resources are created once and shared between endpoints.
+func NewAPIGateway(ctx *pulumi.Context, name string, config *APIGatewayConfig, opts ...pulumi.ResourceOption) (*APIGateway, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &APIGateway{}
+	err := ctx.RegisterComponentResource("denecloud:aws:APIGateway", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	resourceTags := tags.MergeTags(config.Environment, config.Tags)
+
+	if config.CORS != nil && config.CORS.AllowCredentials && config.CORS.allowOrigins() == "*" {
+		return nil, fmt.Errorf("CORS AllowCredentials requires a concrete AllowOrigins entry, not the wildcard %q", "*")
+	}
+
+	if config.APIType == "HTTP" {
+		if config.RequireAPIKey || config.Quota != nil {
+			return nil, fmt.Errorf("API keys and usage plans require APIType REST; they are not supported by HTTP APIs")
+		}
+		if config.WebAclArn != nil {
+			return nil, fmt.Errorf("WebAclArn requires APIType REST; WAF does not support HTTP APIs")
+		}
+		for _, ep := range config.Endpoints {
+			if ep.IntegrationType != "" && ep.IntegrationType != "AWS_PROXY" {
+				return nil, fmt.Errorf("endpoint %s %s: HTTP APIs only support AWS_PROXY Lambda integrations, got %s", ep.Method, ep.Path, ep.IntegrationType)
+			}
+			if ep.LambdaFunction == nil || ep.TargetURI != "" {
+				return nil, fmt.Errorf("endpoint %s %s: HTTP API endpoints require a LambdaFunction and no TargetURI", ep.Method, ep.Path)
+			}
+		}
+		if err := comp.buildHTTP(ctx, name, config, resourceTags, parentOpts); err != nil {
+			return nil, err
+		}
+		if err := comp.registerOutputs(ctx); err != nil {
+			return nil, err
+		}
+		return comp, nil
+	}
+	if config.APIType != "" && config.APIType != "REST" {
+		return nil, fmt.Errorf("unsupported APIType %q: must be REST or HTTP", config.APIType)
+	}
+
+	endpointType := config.EndpointType
+	if endpointType == "" {
+		endpointType = "EDGE"
+	}
+	switch endpointType {
+	case "EDGE", "REGIONAL", "PRIVATE":
+	default:
+		return nil, fmt.Errorf("unsupported EndpointType %q: must be EDGE, REGIONAL or PRIVATE", config.EndpointType)
+	}
+	if len(config.VPCEndpointIds) > 0 && endpointType != "PRIVATE" {
+		return nil, fmt.Errorf("VPCEndpointIds require the PRIVATE endpoint type, got %s", endpointType)
+	}
+
+	endpointConfiguration := &apigateway.RestApiEndpointConfigurationArgs{
+		Types: pulumi.String(endpointType),
+	}
+	if len(config.VPCEndpointIds) > 0 {
+		endpointConfiguration.VpcEndpointIds = pulumi.ToStringArray(config.VPCEndpointIds)
+	}
+	openAPIBody := config.OpenAPIBody
+	if config.OpenAPIBodyFile != "" {
+		data, err := os.ReadFile(config.OpenAPIBodyFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading OpenAPIBodyFile: %w", err)
+		}
+		openAPIBody = string(data)
+	}
+
+	apiArgs := &apigateway.RestApiArgs{
+		Name:                  pulumi.String(name),
+		Description:           pulumi.String(config.Description),
+		EndpointConfiguration: endpointConfiguration,
+		Tags:                  resourceTags,
+	}
+	if openAPIBody != "" {
+		apiArgs.Body = renderOpenAPIBody(openAPIBody, config.OpenAPIFunctions)
+	}
+	apiOpts := parentOpts
+	if config.ImportID != "" {
+		apiOpts = append([]pulumi.ResourceOption{pulumi.Import(pulumi.ID(config.ImportID))}, parentOpts...)
+	}
+	api, err := apigateway.NewRestApi(ctx, name, apiArgs, apiOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.API = api
+
+	// Functions wired through the OpenAPI document still need to grant
+	// API Gateway invoke permission, just like imperative endpoints.
+	for _, fnName := range sortedKeys(config.OpenAPIFunctions) {
+		_, err := lambda.NewPermission(ctx, fmt.Sprintf("%s-openapi-%s", name, fnName), &lambda.PermissionArgs{
+			Action:    pulumi.String("lambda:InvokeFunction"),
+			Function:  config.OpenAPIFunctions[fnName].Name,
+			Principal: pulumi.String("apigateway.amazonaws.com"),
+			SourceArn: pulumi.Sprintf("%s/*/*", api.ExecutionArn),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var lambdaAuthorizer, cognitoAuthorizer *apigateway.Authorizer
+	if config.LambdaAuthorizer != nil {
+		authorizerType := config.LambdaAuthorizer.AuthorizerType
+		if authorizerType == "" {
+			authorizerType = "TOKEN"
+		}
+		if authorizerType != "TOKEN" && authorizerType != "REQUEST" {
+			return nil, fmt.Errorf("unsupported AuthorizerType %q: must be TOKEN or REQUEST", config.LambdaAuthorizer.AuthorizerType)
+		}
+		identitySources := config.LambdaAuthorizer.IdentitySources
+		if len(identitySources) == 0 {
+			if authorizerType == "REQUEST" {
+				return nil, fmt.Errorf("REQUEST authorizers require at least one identity source")
+			}
+			identitySources = []string{"method.request.header.Authorization"}
+		}
+		authorizerName := fmt.Sprintf("%s-lambda-authorizer", name)
+		authorizerArgs := &apigateway.AuthorizerArgs{
+			RestApi:        api.ID(),
+			Name:           pulumi.String(authorizerName),
+			Type:           pulumi.String(authorizerType),
+			AuthorizerUri:  config.LambdaAuthorizer.Function.InvokeArn,
+			IdentitySource: pulumi.String(strings.Join(identitySources, ",")),
+		}
+		if config.LambdaAuthorizer.ResultTTLSeconds > 0 {
+			authorizerArgs.AuthorizerResultTtlInSeconds = pulumi.Int(config.LambdaAuthorizer.ResultTTLSeconds)
+		}
+		lambdaAuthorizer, err = apigateway.NewAuthorizer(ctx, authorizerName, authorizerArgs, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = lambda.NewPermission(ctx, authorizerName, &lambda.PermissionArgs{
+			Action:    pulumi.String("lambda:InvokeFunction"),
+			Function:  config.LambdaAuthorizer.Function.Name,
+			Principal: pulumi.String("apigateway.amazonaws.com"),
+			SourceArn: pulumi.Sprintf("%s/authorizers/%s", api.ExecutionArn, lambdaAuthorizer.ID()),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.CognitoAuthorizer != nil {
+		authorizerName := fmt.Sprintf("%s-cognito-authorizer", name)
+		cognitoAuthorizer, err = apigateway.NewAuthorizer(ctx, authorizerName, &apigateway.AuthorizerArgs{
+			RestApi:        api.ID(),
+			Name:           pulumi.String(authorizerName),
+			Type:           pulumi.String("COGNITO_USER_POOLS"),
+			ProviderArns:   pulumi.ToStringArray(config.CognitoAuthorizer.ProviderARNs),
+			IdentitySource: pulumi.String("method.request.header.Authorization"),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vpcLinks := map[string]*apigateway.VpcLink{}
+	for _, link := range config.VpcLinks {
+		created, err := apigateway.NewVpcLink(ctx, fmt.Sprintf("%s-%s", name, link.Name), &apigateway.VpcLinkArgs{
+			Name:      pulumi.String(link.Name),
+			TargetArn: pulumi.String(link.TargetArn),
+			Tags:      resourceTags,
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		vpcLinks[link.Name] = created
+	}
+
+	// Cache of created path resources keyed by full path so endpoints
+	// sharing a prefix (e.g. /users and /users/{id}) reuse them.
+	resources := map[string]pulumi.StringInput{}
+	// Resources that received at least one method, for CORS handling.
+	corsTargets := map[string]pulumi.StringInput{}
+	// Methods and integrations the deployment must wait for; the
+	// Triggers map alone does not order creation, so without an
+	// explicit dependency the first apply can deploy an API whose
+	// methods have no integration yet.
+	var deploymentDeps []pulumi.Resource
+
+	for _, ep := range config.Endpoints {
+		segments := splitPath(ep.Path)
+		parentID := pulumi.StringInput(api.RootResourceId)
+		fullPath := ""
+		for _, segment := range segments {
+			fullPath += "/" + segment
+			if existing, ok := resources[fullPath]; ok {
+				parentID = existing
+				continue
+			}
+			res, err := apigateway.NewResource(ctx, fmt.Sprintf("%s%s", name, resourceSuffix(fullPath)), &apigateway.ResourceArgs{
+				RestApi:  api.ID(),
+				ParentId: parentID,
+				PathPart: pulumi.String(segment),
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			resources[fullPath] = res.ID()
+			parentID = res.ID()
+		}
+		corsTargets[fullPath] = parentID
+
+		methodName := fmt.Sprintf("%s%s-%s", name, resourceSuffix(fullPath), strings.ToLower(ep.Method))
+		authorization := ep.Authorization
+		if authorization == "" {
+			authorization = "NONE"
+		}
+		methodArgs := &apigateway.MethodArgs{
+			RestApi:        api.ID(),
+			ResourceId:     parentID,
+			HttpMethod:     pulumi.String(ep.Method),
+			Authorization:  pulumi.String(authorization),
+			ApiKeyRequired: pulumi.Bool(ep.APIKeyRequired),
+		}
+		switch authorization {
+		case "CUSTOM":
+			if lambdaAuthorizer == nil {
+				return nil, fmt.Errorf("endpoint %s %s uses CUSTOM authorization but no LambdaAuthorizer is configured", ep.Method, ep.Path)
+			}
+			methodArgs.AuthorizerId = lambdaAuthorizer.ID()
+		case "COGNITO_USER_POOLS":
+			if cognitoAuthorizer == nil {
+				return nil, fmt.Errorf("endpoint %s %s uses COGNITO_USER_POOLS authorization but no CognitoAuthorizer is configured", ep.Method, ep.Path)
+			}
+			methodArgs.AuthorizerId = cognitoAuthorizer.ID()
+		}
+		params := pulumi.BoolMap{}
+		for k, v := range ep.RequestParameters {
+			params[k] = pulumi.Bool(v)
+		}
+		// Greedy proxy endpoints need the proxy path parameter declared
+		// so it reaches the integration.
+		if len(segments) > 0 && segments[len(segments)-1] == "{proxy+}" {
+			params["method.request.path.proxy"] = pulumi.Bool(true)
+		}
+		if len(params) > 0 {
+			methodArgs.RequestParameters = params
+		}
+		if len(ep.RequestModels) > 0 || len(ep.RequestSchemas) > 0 {
+			models := pulumi.StringMap{}
+			for k, v := range ep.RequestModels {
+				models[k] = pulumi.String(v)
+			}
+			for _, contentType := range sortedKeys(ep.RequestSchemas) {
+				modelName := modelResourceName(methodName, contentType)
+				model, err := apigateway.NewModel(ctx, modelName, &apigateway.ModelArgs{
+					RestApi:     api.ID(),
+					Name:        pulumi.String(modelName),
+					ContentType: pulumi.String(contentType),
+					Schema:      pulumi.String(ep.RequestSchemas[contentType]),
+				}, parentOpts...)
+				if err != nil {
+					return nil, err
+				}
+				models[contentType] = model.Name
+			}
+			methodArgs.RequestModels = models
+		}
+		if ep.ValidateRequestBody || ep.ValidateRequestParameters {
+			validator, err := apigateway.NewRequestValidator(ctx, fmt.Sprintf("%s-validator", methodName), &apigateway.RequestValidatorArgs{
+				RestApi:                   api.ID(),
+				Name:                      pulumi.Sprintf("%s-validator", methodName),
+				ValidateRequestBody:       pulumi.Bool(ep.ValidateRequestBody),
+				ValidateRequestParameters: pulumi.Bool(ep.ValidateRequestParameters),
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			methodArgs.RequestValidatorId = validator.ID()
+		}
+		method, err := apigateway.NewMethod(ctx, methodName, methodArgs, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		deploymentDeps = append(deploymentDeps, method)
+
+		integrationType := ep.IntegrationType
+		if integrationType == "" {
+			integrationType = "AWS_PROXY"
+		}
+		integrationArgs := &apigateway.IntegrationArgs{
+			RestApi:    api.ID(),
+			ResourceId: parentID,
+			HttpMethod: pulumi.String(ep.Method),
+			Type:       pulumi.String(integrationType),
+		}
+		switch integrationType {
+		case "AWS_PROXY":
+			if ep.LambdaFunction == nil || ep.TargetURI != "" {
+				return nil, fmt.Errorf("endpoint %s %s: AWS_PROXY integrations require a LambdaFunction and no TargetURI", ep.Method, ep.Path)
+			}
+			integrationArgs.IntegrationHttpMethod = pulumi.String("POST")
+			integrationArgs.Uri = ep.LambdaFunction.InvokeArn
+		case "HTTP_PROXY":
+			if ep.TargetURI == "" || ep.LambdaFunction != nil {
+				return nil, fmt.Errorf("endpoint %s %s: HTTP_PROXY integrations require a TargetURI and no LambdaFunction", ep.Method, ep.Path)
+			}
+			integrationArgs.IntegrationHttpMethod = pulumi.String(ep.Method)
+			integrationArgs.Uri = pulumi.String(ep.TargetURI)
+		case "AWS":
+			if ep.TargetURI == "" || ep.LambdaFunction != nil {
+				return nil, fmt.Errorf("endpoint %s %s: AWS integrations require a TargetURI and no LambdaFunction", ep.Method, ep.Path)
+			}
+			integrationArgs.IntegrationHttpMethod = pulumi.String("POST")
+			integrationArgs.Uri = pulumi.String(ep.TargetURI)
+			if ep.CredentialsArn != "" {
+				integrationArgs.Credentials = pulumi.String(ep.CredentialsArn)
+			}
+			if len(ep.IntegrationRequestTemplates) > 0 {
+				templates := pulumi.StringMap{}
+				for k, v := range ep.IntegrationRequestTemplates {
+					templates[k] = pulumi.String(v)
+				}
+				integrationArgs.RequestTemplates = templates
+			}
+		default:
+			return nil, fmt.Errorf("endpoint %s %s: unsupported IntegrationType %q", ep.Method, ep.Path, ep.IntegrationType)
+		}
+		if ep.ConnectionType == "VPC_LINK" {
+			if integrationType != "HTTP_PROXY" {
+				return nil, fmt.Errorf("endpoint %s %s: VPC_LINK connections require an HTTP_PROXY integration, got %s", ep.Method, ep.Path, integrationType)
+			}
+			integrationArgs.ConnectionType = pulumi.String("VPC_LINK")
+			integrationArgs.ConnectionId = vpcLinks[ep.VpcLinkName].ID()
+		}
+		if ep.TimeoutMilliseconds != 0 {
+			if ep.TimeoutMilliseconds < 50 || ep.TimeoutMilliseconds > 29000 {
+				return nil, fmt.Errorf("endpoint %s %s: TimeoutMilliseconds must be between 50 and 29000, got %d", ep.Method, ep.Path, ep.TimeoutMilliseconds)
+			}
+			integrationArgs.TimeoutMilliseconds = pulumi.Int(ep.TimeoutMilliseconds)
+		}
+		integration, err := apigateway.NewIntegration(ctx, methodName, integrationArgs, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		deploymentDeps = append(deploymentDeps, integration)
+
+		if ep.LambdaFunction != nil {
+			_, err = lambda.NewPermission(ctx, methodName, &lambda.PermissionArgs{
+				Action:    pulumi.String("lambda:InvokeFunction"),
+				Function:  ep.LambdaFunction.Name,
+				Principal: pulumi.String("apigateway.amazonaws.com"),
+				SourceArn: pulumi.Sprintf("%s/*/*", api.ExecutionArn),
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if config.EnableCORS || config.CORS != nil {
+		for fullPath, resourceID := range corsTargets {
+			corsName := fmt.Sprintf("%s%s-options", name, resourceSuffix(fullPath))
+			method, err := apigateway.NewMethod(ctx, corsName, &apigateway.MethodArgs{
+				RestApi:       api.ID(),
+				ResourceId:    resourceID,
+				HttpMethod:    pulumi.String("OPTIONS"),
+				Authorization: pulumi.String("NONE"),
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			integration, err := apigateway.NewIntegration(ctx, corsName, &apigateway.IntegrationArgs{
+				RestApi:    api.ID(),
+				ResourceId: resourceID,
+				HttpMethod: pulumi.String("OPTIONS"),
+				Type:       pulumi.String("MOCK"),
+				RequestTemplates: pulumi.StringMap{
+					"application/json": pulumi.String(`{"statusCode": 200}`),
+				},
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			deploymentDeps = append(deploymentDeps, method, integration)
+			_, err = apigateway.NewMethodResponse(ctx, corsName, &apigateway.MethodResponseArgs{
+				RestApi:            api.ID(),
+				ResourceId:         resourceID,
+				HttpMethod:         pulumi.String("OPTIONS"),
+				StatusCode:         pulumi.String("200"),
+				ResponseParameters: corsMethodResponseParameters(config.CORS),
+			}, append(parentOpts, pulumi.DependsOn([]pulumi.Resource{method}))...)
+			if err != nil {
+				return nil, err
+			}
+			_, err = apigateway.NewIntegrationResponse(ctx, corsName, &apigateway.IntegrationResponseArgs{
+				RestApi:            api.ID(),
+				ResourceId:         resourceID,
+				HttpMethod:         pulumi.String("OPTIONS"),
+				StatusCode:         pulumi.String("200"),
+				ResponseParameters: corsResponseParameters(config.CORS),
+			}, append(parentOpts, pulumi.DependsOn([]pulumi.Resource{integration}))...)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	corsEnabled := config.EnableCORS || config.CORS != nil
+	gatewayResponses := config.GatewayResponses
+	if len(gatewayResponses) == 0 && corsEnabled {
+		gatewayResponses = []GatewayResponseConfig{
+			{ResponseType: "DEFAULT_4XX"},
+			{ResponseType: "DEFAULT_5XX"},
+		}
+	}
+	for _, gr := range gatewayResponses {
+		responseArgs := &apigateway.ResponseArgs{
+			RestApiId:    api.ID(),
+			ResponseType: pulumi.String(gr.ResponseType),
+		}
+		if gr.StatusCode != "" {
+			responseArgs.StatusCode = pulumi.String(gr.StatusCode)
+		}
+		if len(gr.ResponseTemplates) > 0 {
+			templates := pulumi.StringMap{}
+			for k, v := range gr.ResponseTemplates {
+				templates[k] = pulumi.String(v)
+			}
+			responseArgs.ResponseTemplates = templates
+		}
+		if corsEnabled {
+			responseArgs.ResponseParameters = pulumi.StringMap{
+				"gatewayresponse.header.Access-Control-Allow-Origin":  pulumi.Sprintf("'%s'", config.CORS.allowOrigins()),
+				"gatewayresponse.header.Access-Control-Allow-Headers": pulumi.Sprintf("'%s'", config.CORS.allowHeaders()),
+			}
+		}
+		responseName := fmt.Sprintf("%s-%s", name, strings.ToLower(strings.ReplaceAll(gr.ResponseType, "_", "-")))
+		if _, err := apigateway.NewResponse(ctx, responseName, responseArgs, parentOpts...); err != nil {
+			return nil, err
+		}
+	}
+
+	hash := deploymentHash(config, openAPIBody)
+	comp.DeploymentHash = pulumi.String(hash).ToStringOutput()
+	deploymentOpts := parentOpts
+	if len(deploymentDeps) > 0 {
+		deploymentOpts = append(deploymentOpts, pulumi.DependsOn(deploymentDeps))
+	}
+	deployment, err := apigateway.NewDeployment(ctx, name, &apigateway.DeploymentArgs{
+		RestApi: api.ID(),
+		Triggers: pulumi.StringMap{
+			"configHash": pulumi.String(hash),
+		},
+	}, deploymentOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	stages := config.Stages
+	if len(stages) == 0 {
+		stageName := config.StageName
+		if stageName == "" {
+			stageName = config.Environment
+		}
+		stages = []StageConfig{{
+			Name:              stageName,
+			Variables:         config.StageVariables,
+			Throttle:          config.Throttle,
+			AccessLog:         config.AccessLog,
+			EnableXRayTracing: config.EnableXRayTracing,
+		}}
+	}
+	comp.StageURLs = map[string]pulumi.StringOutput{}
+	var createdStages []*apigateway.Stage
+	for _, sc := range stages {
+		stage, err := comp.buildStage(ctx, name, sc, config, api, deployment, resourceTags, parentOpts)
+		if err != nil {
+			return nil, err
+		}
+		createdStages = append(createdStages, stage)
+		comp.StageURLs[sc.Name] = stage.InvokeUrl
+	}
+	comp.Stage = createdStages[0]
+	comp.BaseURL = createdStages[0].InvokeUrl
+	stage := createdStages[0]
+
+	if config.WebAclArn != nil {
+		for i, created := range createdStages {
+			_, err := wafv2.NewWebAclAssociation(ctx, fmt.Sprintf("%s-waf-%s", name, stages[i].Name), &wafv2.WebAclAssociationArgs{
+				ResourceArn: created.Arn,
+				WebAclArn:   config.WebAclArn,
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	requireKey := config.RequireAPIKey
+	for _, ep := range config.Endpoints {
+		if ep.APIKeyRequired {
+			requireKey = true
+		}
+	}
+	if requireKey {
+		apiKey, err := apigateway.NewApiKey(ctx, name, &apigateway.ApiKeyArgs{
+			Name: pulumi.Sprintf("%s-key", name),
+			Tags: resourceTags,
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		comp.APIKey = apiKey
+
+		apiStages := apigateway.UsagePlanApiStageArray{}
+		for _, created := range createdStages {
+			apiStages = append(apiStages, &apigateway.UsagePlanApiStageArgs{
+				ApiId: api.ID(),
+				Stage: created.StageName,
+			})
+		}
+		planArgs := &apigateway.UsagePlanArgs{
+			Name:      pulumi.Sprintf("%s-plan", name),
+			ApiStages: apiStages,
+			Tags:      resourceTags,
+		}
+		if config.Quota != nil {
+			planArgs.QuotaSettings = &apigateway.UsagePlanQuotaSettingsArgs{
+				Limit:  pulumi.Int(config.Quota.Limit),
+				Period: pulumi.String(config.Quota.Period),
+			}
+		}
+		if config.Throttle != nil {
+			planArgs.ThrottleSettings = &apigateway.UsagePlanThrottleSettingsArgs{
+				BurstLimit: pulumi.Int(config.Throttle.BurstLimit),
+				RateLimit:  pulumi.Float64(config.Throttle.RateLimit),
+			}
+		}
+		plan, err := apigateway.NewUsagePlan(ctx, name, planArgs, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		_, err = apigateway.NewUsagePlanKey(ctx, name, &apigateway.UsagePlanKeyArgs{
+			KeyId:       apiKey.ID(),
+			KeyType:     pulumi.String("API_KEY"),
+			UsagePlanId: plan.ID(),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.ApiKeys) > 0 {
+		comp.APIKeyIDs = map[string]pulumi.StringOutput{}
+		comp.APIKeyValues = map[string]pulumi.StringOutput{}
+		apiStages := apigateway.UsagePlanApiStageArray{}
+		for _, created := range createdStages {
+			apiStages = append(apiStages, &apigateway.UsagePlanApiStageArgs{
+				ApiId: api.ID(),
+				Stage: created.StageName,
+			})
+		}
+		apiKeysByName := map[string]*apigateway.ApiKey{}
+		for _, kc := range config.ApiKeys {
+			keyArgs := &apigateway.ApiKeyArgs{
+				Name:    pulumi.Sprintf("%s-%s", name, kc.Name),
+				Enabled: pulumi.Bool(!kc.Disabled),
+				Tags:    resourceTags,
+			}
+			if kc.Value != "" {
+				keyArgs.Value = pulumi.String(kc.Value)
+			}
+			key, err := apigateway.NewApiKey(ctx, fmt.Sprintf("%s-%s", name, kc.Name), keyArgs, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			apiKeysByName[kc.Name] = key
+			comp.APIKeyIDs[kc.Name] = key.ID().ToStringOutput()
+			comp.APIKeyValues[kc.Name] = key.Value
+		}
+		for _, pc := range config.UsagePlans {
+			planArgs := &apigateway.UsagePlanArgs{
+				Name:      pulumi.Sprintf("%s-%s", name, pc.Name),
+				ApiStages: apiStages,
+				Tags:      resourceTags,
+			}
+			if pc.Quota != nil {
+				planArgs.QuotaSettings = &apigateway.UsagePlanQuotaSettingsArgs{
+					Limit:  pulumi.Int(pc.Quota.Limit),
+					Period: pulumi.String(pc.Quota.Period),
+				}
+			}
+			if pc.Throttle != nil {
+				planArgs.ThrottleSettings = &apigateway.UsagePlanThrottleSettingsArgs{
+					BurstLimit: pulumi.Int(pc.Throttle.BurstLimit),
+					RateLimit:  pulumi.Float64(pc.Throttle.RateLimit),
+				}
+			}
+			plan, err := apigateway.NewUsagePlan(ctx, fmt.Sprintf("%s-%s", name, pc.Name), planArgs, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			for _, keyName := range pc.KeyNames {
+				_, err := apigateway.NewUsagePlanKey(ctx, fmt.Sprintf("%s-%s-%s", name, pc.Name, keyName), &apigateway.UsagePlanKeyArgs{
+					KeyId:       apiKeysByName[keyName].ID(),
+					KeyType:     pulumi.String("API_KEY"),
+					UsagePlanId: plan.ID(),
+				}, parentOpts...)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	customDomains := config.CustomDomains
+	if config.CustomDomain != nil {
+		customDomains = append([]CustomDomainConfig{*config.CustomDomain}, customDomains...)
+	}
+	if len(customDomains) > 0 {
+		comp.DomainURLs = make(map[string]pulumi.StringOutput, len(customDomains))
+		for i := range customDomains {
+			// The first domain keeps the historical resource name so
+			// existing stacks upgrading from the singular CustomDomain
+			// field do not replace it.
+			domainName := name
+			if i > 0 {
+				domainName = fmt.Sprintf("%s-domain-%d", name, i)
+			}
+			if err := comp.buildCustomDomain(ctx, domainName, &customDomains[i], endpointType, api.ID(), stage.StageName, parentOpts); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := comp.registerOutputs(ctx); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
+
+// ExportOpenAPI returns the deployed API definition as an OpenAPI 3
+// document with integration extensions, suitable for client SDK
+// generation. The export targets the first stage and only resolves
+// once the stage exists. HTTP APIs have no REST export and yield an
+// empty document.
+func (comp *APIGateway) ExportOpenAPI(ctx *pulumi.Context) pulumi.StringOutput {
+	if comp.API == nil || comp.Stage == nil {
+		return pulumi.String("").ToStringOutput()
+	}
+	return apigateway.GetExportOutput(ctx, apigateway.GetExportOutputArgs{
+		RestApiId:  comp.API.ID(),
+		StageName:  comp.Stage.StageName,
+		ExportType: pulumi.String("oas30"),
+		Accepts:    pulumi.String("application/json"),
+		Parameters: pulumi.StringMap{"extensions": pulumi.String("integrations")},
+	}).Body()
+}
+
+// registerOutputs records the component's output properties in the
+// Pulumi state graph. Optional outputs are included only when the
+// corresponding feature set them.
+func (comp *APIGateway) registerOutputs(ctx *pulumi.Context) error {
+	outputs := pulumi.Map{
+		"baseUrl": comp.BaseURL,
+	}
+	for key, value := range map[string]pulumi.StringOutput{
+		"deploymentHash":     comp.DeploymentHash,
+		"accessLogGroupName": comp.AccessLogGroupName,
+		"domainFqdn":         comp.DomainFQDN,
+	} {
+		if value != (pulumi.StringOutput{}) {
+			outputs[key] = value
+		}
+	}
+	return ctx.RegisterResourceOutputs(comp, outputs)
+}
+
+// buildStage creates one stage sharing the API deployment, together
+// with its access logging, X-Ray and throttling settings.
+func (comp *APIGateway) buildStage(ctx *pulumi.Context, name string, sc StageConfig, config *APIGatewayConfig, api *apigateway.RestApi, deployment *apigateway.Deployment, resourceTags pulumi.StringMap, parentOpts []pulumi.ResourceOption) (*apigateway.Stage, error) {
+	prefix := fmt.Sprintf("%s-%s", name, sc.Name)
+	stageArgs := &apigateway.StageArgs{
+		RestApi:    api.ID(),
+		Deployment: deployment.ID(),
+		StageName:  pulumi.String(sc.Name),
+		Tags:       resourceTags,
+	}
+	if sc.EnableXRayTracing {
+		stageArgs.XrayTracingEnabled = pulumi.Bool(true)
+	}
+	if len(sc.Variables) > 0 {
+		variables := pulumi.StringMap{}
+		for k, v := range sc.Variables {
+			variables[k] = pulumi.String(v)
+		}
+		stageArgs.Variables = variables
+	}
+	if sc.AccessLog != nil && sc.AccessLog.Enabled {
+		format := sc.AccessLog.resolveFormat()
+		destination := pulumi.StringInput(pulumi.String(sc.AccessLog.DestinationArn))
+		if sc.AccessLog.DestinationArn == "" {
+			retention := sc.AccessLog.RetentionDays
+			if retention == 0 {
+				retention = 30
+			}
+			logGroup, err := cloudwatch.NewLogGroup(ctx, fmt.Sprintf("%s-access-logs", prefix), &cloudwatch.LogGroupArgs{
+				Name:            pulumi.Sprintf("/aws/apigateway/%s-access-logs", prefix),
+				RetentionInDays: pulumi.Int(retention),
+				Tags:            resourceTags,
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			destination = logGroup.Arn
+			comp.AccessLogGroupName = logGroup.Name
+		}
+		stageArgs.AccessLogSettings = &apigateway.StageAccessLogSettingsArgs{
+			DestinationArn: destination,
+			Format:         pulumi.String(format),
+		}
+	}
+	stage, err := apigateway.NewStage(ctx, prefix, stageArgs, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	throttle := sc.Throttle
+	if throttle == nil {
+		throttle = config.Throttle
+	}
+	if throttle != nil {
+		_, err := apigateway.NewMethodSettings(ctx, fmt.Sprintf("%s-throttle-default", prefix), &apigateway.MethodSettingsArgs{
+			RestApi:    api.ID(),
+			StageName:  stage.StageName,
+			MethodPath: pulumi.String("*/*"),
+			Settings: &apigateway.MethodSettingsSettingsArgs{
+				ThrottlingBurstLimit: pulumi.Int(throttle.BurstLimit),
+				ThrottlingRateLimit:  pulumi.Float64(throttle.RateLimit),
+			},
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, ep := range config.Endpoints {
+		if ep.ThrottleBurstLimit == 0 && ep.ThrottleRateLimit == 0 {
+			continue
+		}
+		methodPath := fmt.Sprintf("%s/%s", strings.Join(splitPath(ep.Path), "/"), ep.Method)
+		settingsName := fmt.Sprintf("%s%s-%s-throttle", prefix, resourceSuffix("/"+strings.Join(splitPath(ep.Path), "/")), strings.ToLower(ep.Method))
+		_, err := apigateway.NewMethodSettings(ctx, settingsName, &apigateway.MethodSettingsArgs{
+			RestApi:    api.ID(),
+			StageName:  stage.StageName,
+			MethodPath: pulumi.String(methodPath),
+			Settings: &apigateway.MethodSettingsSettingsArgs{
+				ThrottlingBurstLimit: pulumi.Int(ep.ThrottleBurstLimit),
+				ThrottlingRateLimit:  pulumi.Float64(ep.ThrottleRateLimit),
+			},
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return stage, nil
+}
+
+// buildCustomDomain creates the domain name, base path mapping and,
+// when a hosted zone is given, alias A and AAAA records for the domain.
+// The certificate and alias attributes follow the API's endpoint type.
+func (comp *APIGateway) buildCustomDomain(ctx *pulumi.Context, name string, cd *CustomDomainConfig, endpointType string, apiID pulumi.IDOutput, stageName pulumi.StringOutput, parentOpts []pulumi.ResourceOption) error {
+	if endpointType == "PRIVATE" {
+		return fmt.Errorf("custom domains are not supported for PRIVATE endpoint types")
+	}
+	domainArgs := &apigateway.DomainNameArgs{
+		DomainName: pulumi.String(cd.DomainName),
+		EndpointConfiguration: &apigateway.DomainNameEndpointConfigurationArgs{
+			Types: pulumi.String(endpointType),
+		},
+	}
+	if endpointType == "EDGE" {
+		if cd.RegionalCertificateArn != "" {
+			return fmt.Errorf("RegionalCertificateArn only applies to REGIONAL domains; EDGE domains need a us-east-1 CertificateArn")
+		}
+		// Edge-optimized domains serve the certificate from CloudFront,
+		// which only reads ACM certificates in us-east-1.
+		if region := certificateRegion(cd.CertificateArn); region != "" && region != "us-east-1" {
+			return fmt.Errorf("EDGE custom domains require a certificate in us-east-1, got one in %s", region)
+		}
+		domainArgs.CertificateArn = pulumi.String(cd.CertificateArn)
+	} else {
+		certificateArn := cd.CertificateArn
+		if cd.RegionalCertificateArn != "" {
+			certificateArn = cd.RegionalCertificateArn
+		}
+		domainArgs.RegionalCertificateArn = pulumi.String(certificateArn)
+	}
+	if cd.MutualTLS != nil {
+		if endpointType != "REGIONAL" {
+			return fmt.Errorf("mutual TLS requires the REGIONAL endpoint type, got %s", endpointType)
+		}
+		mtls := &apigateway.DomainNameMutualTlsAuthenticationArgs{
+			TruststoreUri: pulumi.String(cd.MutualTLS.TruststoreUri),
+		}
+		if cd.MutualTLS.TruststoreVersion != "" {
+			mtls.TruststoreVersion = pulumi.String(cd.MutualTLS.TruststoreVersion)
+		}
+		domainArgs.MutualTlsAuthentication = mtls
+	}
+	domain, err := apigateway.NewDomainName(ctx, name, domainArgs, parentOpts...)
+	if err != nil {
+		return err
+	}
+	mappingArgs := &apigateway.BasePathMappingArgs{
+		RestApi:    apiID,
+		StageName:  stageName,
+		DomainName: domain.DomainName,
+	}
+	if cd.BasePath != "" {
+		mappingArgs.BasePath = pulumi.String(cd.BasePath)
+	}
+	_, err = apigateway.NewBasePathMapping(ctx, name, mappingArgs, parentOpts...)
+	if err != nil {
+		return err
+	}
+
+	url := "https://" + cd.DomainName
+	if cd.BasePath != "" {
+		url += "/" + cd.BasePath
+	}
+	comp.DomainURLs[cd.DomainName] = pulumi.String(url).ToStringOutput()
+
+	if cd.Route53ZoneId == "" {
+		return nil
+	}
+	// Regional domains alias to the regional attributes; edge-optimized
+	// domains use the CloudFront ones instead.
+	targetName := domain.RegionalDomainName
+	targetZone := domain.RegionalZoneId
+	if endpointType == "EDGE" {
+		targetName = domain.CloudfrontDomainName
+		targetZone = domain.CloudfrontZoneId
+	}
+	for _, recordType := range []string{"A", "AAAA"} {
+		record, err := route53.NewRecord(ctx, fmt.Sprintf("%s-%s", name, strings.ToLower(recordType)), &route53.RecordArgs{
+			Name:   pulumi.String(cd.DomainName),
+			Type:   pulumi.String(recordType),
+			ZoneId: pulumi.String(cd.Route53ZoneId),
+			Aliases: route53.RecordAliasArray{
+				&route53.RecordAliasArgs{
+					Name:                 targetName,
+					ZoneId:               targetZone,
+					EvaluateTargetHealth: pulumi.Bool(false),
+				},
+			},
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+		if recordType == "A" && comp.DomainFQDN == (pulumi.StringOutput{}) {
+			comp.DomainFQDN = record.Fqdn
+		}
+	}
+	return nil
+}
+
+// certificateRegion extracts the region from an ACM certificate ARN,
+// or "" when the ARN does not parse.
+func certificateRegion(arn string) string {
+	parts := strings.Split(arn, ":")
+	if len(parts) < 4 {
+		return ""
+	}
+	return parts[3]
+}
+
+// buildHTTP assembles the component as an API Gateway v2 HTTP API with
+// AWS_PROXY Lambda integrations.
+func (comp *APIGateway) buildHTTP(ctx *pulumi.Context, name string, config *APIGatewayConfig, resourceTags pulumi.StringMap, parentOpts []pulumi.ResourceOption) error {
+	apiArgs := &apigatewayv2.ApiArgs{
+		Name:         pulumi.String(name),
+		ProtocolType: pulumi.String("HTTP"),
+		Description:  pulumi.String(config.Description),
+		Tags:         resourceTags,
+	}
+	if config.EnableCORS || config.CORS != nil {
+		corsArgs := &apigatewayv2.ApiCorsConfigurationArgs{
+			AllowOrigins: pulumi.ToStringArray(strings.Split(config.CORS.allowOrigins(), ",")),
+			AllowHeaders: pulumi.ToStringArray(strings.Split(config.CORS.allowHeaders(), ",")),
+			AllowMethods: pulumi.ToStringArray(strings.Split(config.CORS.allowMethods(), ",")),
+		}
+		if config.CORS != nil {
+			if len(config.CORS.ExposeHeaders) > 0 {
+				corsArgs.ExposeHeaders = pulumi.ToStringArray(config.CORS.ExposeHeaders)
+			}
+			if config.CORS.MaxAgeSeconds > 0 {
+				corsArgs.MaxAge = pulumi.Int(config.CORS.MaxAgeSeconds)
+			}
+			if config.CORS.AllowCredentials {
+				corsArgs.AllowCredentials = pulumi.Bool(true)
+			}
+		}
+		apiArgs.CorsConfiguration = corsArgs
+	}
+	api, err := apigatewayv2.NewApi(ctx, name, apiArgs, parentOpts...)
+	if err != nil {
+		return err
+	}
+	comp.HTTPAPI = api
+
+	for _, ep := range config.Endpoints {
+		routeName := fmt.Sprintf("%s%s-%s", name, resourceSuffix("/"+strings.Join(splitPath(ep.Path), "/")), strings.ToLower(ep.Method))
+		integration, err := apigatewayv2.NewIntegration(ctx, routeName, &apigatewayv2.IntegrationArgs{
+			ApiId:                api.ID(),
+			IntegrationType:      pulumi.String("AWS_PROXY"),
+			IntegrationUri:       ep.LambdaFunction.InvokeArn,
+			IntegrationMethod:    pulumi.String("POST"),
+			PayloadFormatVersion: pulumi.String("2.0"),
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+		routeKey := fmt.Sprintf("%s /%s", ep.Method, strings.Join(splitPath(ep.Path), "/"))
+		_, err = apigatewayv2.NewRoute(ctx, routeName, &apigatewayv2.RouteArgs{
+			ApiId:    api.ID(),
+			RouteKey: pulumi.String(routeKey),
+			Target:   pulumi.Sprintf("integrations/%s", integration.ID()),
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+		_, err = lambda.NewPermission(ctx, routeName, &lambda.PermissionArgs{
+			Action:    pulumi.String("lambda:InvokeFunction"),
+			Function:  ep.LambdaFunction.Name,
+			Principal: pulumi.String("apigateway.amazonaws.com"),
+			SourceArn: pulumi.Sprintf("%s/*/*", api.ExecutionArn),
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+	}
+
+	stageName := config.StageName
+	if stageName == "" {
+		stageName = config.Environment
+	}
+	stage, err := apigatewayv2.NewStage(ctx, name, &apigatewayv2.StageArgs{
+		ApiId:      api.ID(),
+		Name:       pulumi.String(stageName),
+		AutoDeploy: pulumi.Bool(true),
+		Tags:       resourceTags,
+	}, parentOpts...)
+	if err != nil {
+		return err
+	}
+	comp.BaseURL = stage.InvokeUrl
+	return nil
+}
+
+// renderOpenAPIBody substitutes ${lambda:<name>} placeholders in the
+// OpenAPI document with the invoke ARNs of the mapped functions.
+func renderOpenAPIBody(body string, functions map[string]*lambda.Function) pulumi.StringPtrInput {
+	if len(functions) == 0 {
+		return pulumi.String(body)
+	}
+	names := sortedKeys(functions)
+	arns := make([]interface{}, 0, len(names))
+	for _, fnName := range names {
+		arns = append(arns, functions[fnName].InvokeArn)
+	}
+	return pulumi.All(arns...).ApplyT(func(resolved []interface{}) string {
+		rendered := body
+		for i, fnName := range names {
+			rendered = strings.ReplaceAll(rendered, "${lambda:"+fnName+"}", resolved[i].(string))
+		}
+		return rendered
+	}).(pulumi.StringOutput).ToStringPtrOutput()
+}
+
+// deploymentHash computes a deterministic SHA-256 digest over the parts
+// of the config that define the deployed API: endpoint paths, methods,
+// authorization, API key flags, request parameters/models and CORS.
+// Pointer-valued fields such as the Lambda function are deliberately
+// excluded so the hash is stable across program runs.
+func deploymentHash(config *APIGatewayConfig, openAPIBody string) string {
+	h := sha256.New()
+	if openAPIBody != "" {
+		fmt.Fprintf(h, "openapi|%s\n", openAPIBody)
+	}
+	for _, ep := range config.Endpoints {
+		fmt.Fprintf(h, "endpoint|%s|%s|%s|%t\n", ep.Path, ep.Method, ep.Authorization, ep.APIKeyRequired)
+		for _, k := range sortedKeys(ep.RequestParameters) {
+			fmt.Fprintf(h, "param|%s=%t\n", k, ep.RequestParameters[k])
+		}
+		for _, k := range sortedKeys(ep.RequestModels) {
+			fmt.Fprintf(h, "model|%s=%s\n", k, ep.RequestModels[k])
+		}
+		for _, k := range sortedKeys(ep.RequestSchemas) {
+			fmt.Fprintf(h, "schema|%s=%s\n", k, ep.RequestSchemas[k])
+		}
+		fmt.Fprintf(h, "validate|%t|%t\n", ep.ValidateRequestBody, ep.ValidateRequestParameters)
+	}
+	fmt.Fprintf(h, "cors|%t|%s|%s|%s\n", config.EnableCORS || config.CORS != nil,
+		config.CORS.allowOrigins(), config.CORS.allowHeaders(), config.CORS.allowMethods())
+	if config.CORS != nil {
+		fmt.Fprintf(h, "cors-extra|%s|%d|%t\n", strings.Join(config.CORS.ExposeHeaders, ","),
+			config.CORS.MaxAgeSeconds, config.CORS.AllowCredentials)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// corsMethodResponseParameters declares which CORS headers the OPTIONS
+// method response may carry.
+func corsMethodResponseParameters(cors *CORSConfig) pulumi.BoolMap {
+	params := pulumi.BoolMap{
+		"method.response.header.Access-Control-Allow-Origin":  pulumi.Bool(true),
+		"method.response.header.Access-Control-Allow-Headers": pulumi.Bool(true),
+		"method.response.header.Access-Control-Allow-Methods": pulumi.Bool(true),
+	}
+	if cors != nil {
+		if len(cors.ExposeHeaders) > 0 {
+			params["method.response.header.Access-Control-Expose-Headers"] = pulumi.Bool(true)
+		}
+		if cors.MaxAgeSeconds > 0 {
+			params["method.response.header.Access-Control-Max-Age"] = pulumi.Bool(true)
+		}
+		if cors.AllowCredentials {
+			params["method.response.header.Access-Control-Allow-Credentials"] = pulumi.Bool(true)
+		}
+	}
+	return params
+}
+
+// corsResponseParameters maps the configured CORS values onto the
+// OPTIONS integration response headers. Values are single-quoted as
+// required for static API Gateway response parameters.
+func corsResponseParameters(cors *CORSConfig) pulumi.StringMap {
+	params := pulumi.StringMap{
+		"method.response.header.Access-Control-Allow-Origin":  pulumi.Sprintf("'%s'", cors.allowOrigins()),
+		"method.response.header.Access-Control-Allow-Headers": pulumi.Sprintf("'%s'", cors.allowHeaders()),
+		"method.response.header.Access-Control-Allow-Methods": pulumi.Sprintf("'%s'", cors.allowMethods()),
+	}
+	if cors != nil {
+		if len(cors.ExposeHeaders) > 0 {
+			params["method.response.header.Access-Control-Expose-Headers"] = pulumi.Sprintf("'%s'", strings.Join(cors.ExposeHeaders, ","))
+		}
+		if cors.MaxAgeSeconds > 0 {
+			params["method.response.header.Access-Control-Max-Age"] = pulumi.Sprintf("'%d'", cors.MaxAgeSeconds)
+		}
+		if cors.AllowCredentials {
+			params["method.response.header.Access-Control-Allow-Credentials"] = pulumi.String("'true'")
+		}
+	}
+	return params
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// splitPath breaks a request path into its API Gateway path parts.
+// Leading and trailing slashes are ignored, so "/" yields no segments.
+// Parameter segments such as {id} and greedy proxies such as {proxy+}
+// are preserved verbatim.
+func splitPath(path string) []string {
+	segments := []string{}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+// modelResourceName builds an alphanumeric model name, as required by
+// API Gateway, from the method name and content type.
+func modelResourceName(methodName, contentType string) string {
+	var b strings.Builder
+	for _, r := range methodName + contentType {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// resourceSuffix turns a full path into a stable, name-safe suffix,
+// e.g. "/users/{id}" -> "-users-id".
+func resourceSuffix(fullPath string) string {
+	replacer := strings.NewReplacer("/", "-", "{", "", "}", "", "+", "")
+	return replacer.Replace(fullPath)
+}
@@ -0,0 +1,81 @@
+package apigateway
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPath(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		want []string
+	}{
+		{"root", "/", []string{}},
+		{"single segment", "/users", []string{"users"}},
+		{"no leading slash", "users", []string{"users"}},
+		{"trailing slash", "/users/", []string{"users"}},
+		{"nested", "/users/{id}/profile", []string{"users", "{id}", "profile"}},
+		{"deeply nested", "/a/b/c/d/e", []string{"a", "b", "c", "d", "e"}},
+		{"greedy proxy", "/api/{proxy+}", []string{"api", "{proxy+}"}},
+		{"double slash", "/users//profile", []string{"users", "profile"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := splitPath(tc.path)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("splitPath(%q) = %v, want %v", tc.path, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestResourceSuffix(t *testing.T) {
+	cases := map[string]string{
+		"/users":        "-users",
+		"/users/{id}":   "-users-id",
+		"/api/{proxy+}": "-api-proxy",
+		"/a/b/c":        "-a-b-c",
+	}
+	for fullPath, want := range cases {
+		if got := resourceSuffix(fullPath); got != want {
+			t.Errorf("resourceSuffix(%q) = %q, want %q", fullPath, got, want)
+		}
+	}
+}
+
+func TestDeploymentHashDeterministic(t *testing.T) {
+	config := func() *APIGatewayConfig {
+		return &APIGatewayConfig{
+			Environment: "dev",
+			EnableCORS:  true,
+			Endpoints: []EndpointConfig{
+				{
+					Path:              "/users/{id}",
+					Method:            "GET",
+					Authorization:     "NONE",
+					RequestParameters: map[string]bool{"method.request.path.id": true},
+					RequestModels:     map[string]string{"application/json": "UserRequest"},
+				},
+				{Path: "/users", Method: "POST", APIKeyRequired: true},
+			},
+		}
+	}
+
+	a, b := deploymentHash(config(), ""), deploymentHash(config(), "")
+	if a != b {
+		t.Errorf("identical configs hashed differently: %s vs %s", a, b)
+	}
+
+	changedMethod := config()
+	changedMethod.Endpoints[0].Method = "PUT"
+	if deploymentHash(changedMethod, "") == a {
+		t.Error("changing a method did not change the hash")
+	}
+
+	changedPath := config()
+	changedPath.Endpoints[1].Path = "/accounts"
+	if deploymentHash(changedPath, "") == a {
+		t.Error("changing a path did not change the hash")
+	}
+}
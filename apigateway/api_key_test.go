@@ -0,0 +1,52 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestEndpointOnlyAPIKeyCreatesKeyAndPlan(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn, APIKeyRequired: true},
+			},
+		})
+		return err
+	})
+
+	if keys := mocks.ByType("aws:apigateway/apiKey:ApiKey"); len(keys) != 1 {
+		t.Errorf("expected 1 API key for an endpoint-only key requirement, got %d", len(keys))
+	}
+	if plans := mocks.ByType("aws:apigateway/usagePlan:UsagePlan"); len(plans) != 1 {
+		t.Errorf("expected 1 usage plan, got %d", len(plans))
+	}
+	if links := mocks.ByType("aws:apigateway/usagePlanKey:UsagePlanKey"); len(links) != 1 {
+		t.Errorf("expected 1 usage plan key, got %d", len(links))
+	}
+
+	method := mocks.ByType("aws:apigateway/method:Method")[0]
+	if !method.Inputs["apiKeyRequired"].BoolValue() {
+		t.Error("method should require an API key")
+	}
+}
+
+func TestNoAPIKeyWithoutAnyRequirement(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	if keys := mocks.ByType("aws:apigateway/apiKey:ApiKey"); len(keys) != 0 {
+		t.Errorf("expected no API key, got %d", len(keys))
+	}
+}
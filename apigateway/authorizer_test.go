@@ -0,0 +1,148 @@
+package apigateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const authorizerToken = "aws:apigateway/authorizer:Authorizer"
+
+func TestCognitoAuthorizer(t *testing.T) {
+	poolArn := "arn:aws:cognito-idp:us-east-1:123456789012:userpool/us-east-1_abc"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:       "dev",
+			CognitoAuthorizer: &CognitoAuthorizerConfig{ProviderARNs: []string{poolArn}},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn, Authorization: "COGNITO_USER_POOLS"},
+			},
+		})
+		return err
+	})
+
+	authorizers := mocks.ByType(authorizerToken)
+	if len(authorizers) != 1 {
+		t.Fatalf("expected 1 authorizer, got %d", len(authorizers))
+	}
+	if got := authorizers[0].Inputs["type"].StringValue(); got != "COGNITO_USER_POOLS" {
+		t.Errorf("authorizer type = %s, want COGNITO_USER_POOLS", got)
+	}
+	arns := authorizers[0].Inputs["providerArns"].ArrayValue()
+	if len(arns) != 1 || arns[0].StringValue() != poolArn {
+		t.Errorf("providerArns = %v, want [%s]", arns, poolArn)
+	}
+
+	methods := mocks.ByType("aws:apigateway/method:Method")
+	if len(methods) != 1 {
+		t.Fatalf("expected 1 method, got %d", len(methods))
+	}
+	if methods[0].Inputs["authorizerId"].IsNull() {
+		t.Error("method does not reference the authorizer")
+	}
+}
+
+func TestLambdaAndCognitoAuthorizersCoexist(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		authFn := newTestFunction(t, ctx, "authorizer")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:       "dev",
+			LambdaAuthorizer:  &LambdaAuthorizerConfig{Function: authFn},
+			CognitoAuthorizer: &CognitoAuthorizerConfig{ProviderARNs: []string{"arn:aws:cognito-idp:us-east-1:123456789012:userpool/p"}},
+			Endpoints: []EndpointConfig{
+				{Path: "/admin", Method: "GET", LambdaFunction: fn, Authorization: "CUSTOM"},
+				{Path: "/users", Method: "GET", LambdaFunction: fn, Authorization: "COGNITO_USER_POOLS"},
+			},
+		})
+		return err
+	})
+
+	authorizers := mocks.ByType(authorizerToken)
+	if len(authorizers) != 2 {
+		t.Fatalf("expected 2 authorizers, got %d", len(authorizers))
+	}
+	types := map[string]bool{}
+	for _, a := range authorizers {
+		types[a.Inputs["type"].StringValue()] = true
+	}
+	if !types["TOKEN"] || !types["COGNITO_USER_POOLS"] {
+		t.Errorf("authorizer types = %v, want TOKEN and COGNITO_USER_POOLS", types)
+	}
+	for _, m := range mocks.ByType("aws:apigateway/method:Method") {
+		if m.Inputs["authorizerId"].IsNull() {
+			t.Errorf("method %s has no authorizer reference", m.Name)
+		}
+	}
+}
+
+func TestRequestAuthorizerIdentitySources(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		authFn := newTestFunction(t, ctx, "authorizer")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			LambdaAuthorizer: &LambdaAuthorizerConfig{
+				Function:         authFn,
+				AuthorizerType:   "REQUEST",
+				IdentitySources:  []string{"method.request.header.X-Api-Token", "method.request.querystring.session"},
+				ResultTTLSeconds: 120,
+			},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn, Authorization: "CUSTOM"},
+			},
+		})
+		return err
+	})
+
+	authorizer := mocks.ByType(authorizerToken)[0]
+	if got := authorizer.Inputs["type"].StringValue(); got != "REQUEST" {
+		t.Errorf("authorizer type = %s, want REQUEST", got)
+	}
+	want := "method.request.header.X-Api-Token,method.request.querystring.session"
+	if got := authorizer.Inputs["identitySource"].StringValue(); got != want {
+		t.Errorf("identitySource = %s, want %s", got, want)
+	}
+	if got := authorizer.Inputs["authorizerResultTtlInSeconds"].NumberValue(); got != 120 {
+		t.Errorf("authorizerResultTtlInSeconds = %v, want 120", got)
+	}
+}
+
+func TestRequestAuthorizerRequiresIdentitySource(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		authFn := newTestFunction(t, ctx, "authorizer")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:      "dev",
+			LambdaAuthorizer: &LambdaAuthorizerConfig{Function: authFn, AuthorizerType: "REQUEST"},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil || !strings.Contains(err.Error(), "identity source") {
+		t.Fatalf("expected identity source error, got %v", err)
+	}
+}
+
+func TestTokenAuthorizerDefaults(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		authFn := newTestFunction(t, ctx, "authorizer")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:      "dev",
+			LambdaAuthorizer: &LambdaAuthorizerConfig{Function: authFn},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn, Authorization: "CUSTOM"},
+			},
+		})
+		return err
+	})
+
+	authorizer := mocks.ByType(authorizerToken)[0]
+	if got := authorizer.Inputs["type"].StringValue(); got != "TOKEN" {
+		t.Errorf("authorizer type = %s, want TOKEN", got)
+	}
+	if got := authorizer.Inputs["identitySource"].StringValue(); got != "method.request.header.Authorization" {
+		t.Errorf("identitySource = %s, want Authorization header", got)
+	}
+}
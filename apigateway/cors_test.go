@@ -0,0 +1,121 @@
+package apigateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	methodResponseToken      = "aws:apigateway/methodResponse:MethodResponse"
+	integrationResponseToken = "aws:apigateway/integrationResponse:IntegrationResponse"
+)
+
+func TestCORSPreflightResponses(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			EnableCORS:  true,
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	methodResponses := mocks.ByType(methodResponseToken)
+	if len(methodResponses) != 1 {
+		t.Fatalf("expected 1 method response, got %d", len(methodResponses))
+	}
+	params := methodResponses[0].Inputs["responseParameters"].ObjectValue()
+	for _, header := range []string{"Origin", "Headers", "Methods"} {
+		key := resource.PropertyKey("method.response.header.Access-Control-Allow-" + header)
+		if !params[key].BoolValue() {
+			t.Errorf("method response missing Access-Control-Allow-%s", header)
+		}
+	}
+
+	integrationResponses := mocks.ByType(integrationResponseToken)
+	if len(integrationResponses) != 1 {
+		t.Fatalf("expected 1 integration response, got %d", len(integrationResponses))
+	}
+	values := integrationResponses[0].Inputs["responseParameters"].ObjectValue()
+	if got := values["method.response.header.Access-Control-Allow-Origin"].StringValue(); got != "'*'" {
+		t.Errorf("default allow-origin = %s, want '*'", got)
+	}
+}
+
+func TestCORSCustomOrigins(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			CORS: &CORSConfig{
+				AllowOrigins: []string{"https://example.com"},
+				AllowMethods: []string{"GET", "OPTIONS"},
+			},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	values := mocks.ByType(integrationResponseToken)[0].Inputs["responseParameters"].ObjectValue()
+	if got := values["method.response.header.Access-Control-Allow-Origin"].StringValue(); got != "'https://example.com'" {
+		t.Errorf("allow-origin = %s, want 'https://example.com'", got)
+	}
+	if got := values["method.response.header.Access-Control-Allow-Methods"].StringValue(); got != "'GET,OPTIONS'" {
+		t.Errorf("allow-methods = %s, want 'GET,OPTIONS'", got)
+	}
+}
+
+func TestCORSCredentialsRejectWildcard(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			CORS:        &CORSConfig{AllowCredentials: true},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil || !strings.Contains(err.Error(), "AllowCredentials") {
+		t.Fatalf("expected credentials+wildcard error, got %v", err)
+	}
+}
+
+func TestCORSExtendedHeaders(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			CORS: &CORSConfig{
+				AllowOrigins:     []string{"https://example.com"},
+				ExposeHeaders:    []string{"X-Request-Id"},
+				MaxAgeSeconds:    600,
+				AllowCredentials: true,
+			},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	values := mocks.ByType(integrationResponseToken)[0].Inputs["responseParameters"].ObjectValue()
+	if got := values["method.response.header.Access-Control-Expose-Headers"].StringValue(); got != "'X-Request-Id'" {
+		t.Errorf("expose-headers = %s, want 'X-Request-Id'", got)
+	}
+	if got := values["method.response.header.Access-Control-Max-Age"].StringValue(); got != "'600'" {
+		t.Errorf("max-age = %s, want '600'", got)
+	}
+	if got := values["method.response.header.Access-Control-Allow-Credentials"].StringValue(); got != "'true'" {
+		t.Errorf("allow-credentials = %s, want 'true'", got)
+	}
+}
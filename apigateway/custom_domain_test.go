@@ -0,0 +1,284 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const recordToken = "aws:route53/record:Record"
+
+func TestCustomDomainCreatesAliasRecords(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomain: &CustomDomainConfig{
+				DomainName:     "api.example.com",
+				CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+				Route53ZoneId:  "Z123456",
+			},
+		})
+		return err
+	})
+
+	records := mocks.ByType(recordToken)
+	if len(records) != 2 {
+		t.Fatalf("expected A and AAAA records, got %d", len(records))
+	}
+	types := map[string]bool{}
+	for _, r := range records {
+		types[r.Inputs["type"].StringValue()] = true
+		if got := r.Inputs["zoneId"].StringValue(); got != "Z123456" {
+			t.Errorf("record zoneId = %s, want Z123456", got)
+		}
+		if got := r.Inputs["name"].StringValue(); got != "api.example.com" {
+			t.Errorf("record name = %s, want api.example.com", got)
+		}
+	}
+	if !types["A"] || !types["AAAA"] {
+		t.Errorf("missing record types, got %v", types)
+	}
+}
+
+func TestCustomDomainWithoutZoneSkipsDNS(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomain: &CustomDomainConfig{
+				DomainName:     "api.example.com",
+				CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+			},
+		})
+		return err
+	})
+
+	if records := mocks.ByType(recordToken); len(records) != 0 {
+		t.Fatalf("expected no records, got %d", len(records))
+	}
+	if domains := mocks.ByType("aws:apigateway/domainName:DomainName"); len(domains) != 1 {
+		t.Fatalf("expected 1 domain name, got %d", len(domains))
+	}
+}
+
+func TestMutualTLSSetsTruststore(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:  "dev",
+			EndpointType: "REGIONAL",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomain: &CustomDomainConfig{
+				DomainName:     "partner-api.example.com",
+				CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+				MutualTLS: &MutualTLSConfig{
+					TruststoreUri:     "s3://example-certs/truststore.pem",
+					TruststoreVersion: "3",
+				},
+			},
+		})
+		return err
+	})
+
+	domain := mocks.ByType("aws:apigateway/domainName:DomainName")[0]
+	mtls := domain.Inputs["mutualTlsAuthentication"].ObjectValue()
+	if got := mtls["truststoreUri"].StringValue(); got != "s3://example-certs/truststore.pem" {
+		t.Errorf("truststoreUri = %s, want s3://example-certs/truststore.pem", got)
+	}
+	if got := mtls["truststoreVersion"].StringValue(); got != "3" {
+		t.Errorf("truststoreVersion = %s, want 3", got)
+	}
+}
+
+func TestMutualTLSRejectsEdgeEndpoint(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomain: &CustomDomainConfig{
+				DomainName:     "partner-api.example.com",
+				CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+				MutualTLS: &MutualTLSConfig{
+					TruststoreUri: "s3://example-certs/truststore.pem",
+				},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected mutual TLS on an edge-optimized domain to be rejected")
+	}
+}
+
+func TestMultipleCustomDomainsWithBasePaths(t *testing.T) {
+	wantURLs := map[string]string{
+		"api.example.com":     "https://api.example.com/v1",
+		"partner.example.com": "https://partner.example.com/orders",
+	}
+	urlCount := 0
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		api, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomains: []CustomDomainConfig{
+				{
+					DomainName:     "api.example.com",
+					CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+					BasePath:       "v1",
+				},
+				{
+					DomainName:     "partner.example.com",
+					CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/def",
+					BasePath:       "orders",
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		urlCount = len(api.DomainURLs)
+		for domain, url := range api.DomainURLs {
+			want := wantURLs[domain]
+			url.ApplyT(func(got string) string {
+				if got != want {
+					t.Errorf("URL for %s = %s, want %s", domain, got, want)
+				}
+				return got
+			})
+		}
+		return nil
+	})
+
+	if domains := mocks.ByType("aws:apigateway/domainName:DomainName"); len(domains) != 2 {
+		t.Fatalf("expected 2 domain names, got %d", len(domains))
+	}
+	mappings := mocks.ByType("aws:apigateway/basePathMapping:BasePathMapping")
+	if len(mappings) != 2 {
+		t.Fatalf("expected 2 base path mappings, got %d", len(mappings))
+	}
+	basePaths := map[string]bool{}
+	for _, m := range mappings {
+		basePaths[m.Inputs["basePath"].StringValue()] = true
+	}
+	if !basePaths["v1"] || !basePaths["orders"] {
+		t.Errorf("missing base paths, got %v", basePaths)
+	}
+	if urlCount != 2 {
+		t.Fatalf("expected 2 domain URLs, got %d", urlCount)
+	}
+}
+
+func TestSingularCustomDomainStillWorksAlongsideList(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomain: &CustomDomainConfig{
+				DomainName:     "api.example.com",
+				CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+			},
+			CustomDomains: []CustomDomainConfig{
+				{
+					DomainName:     "partner.example.com",
+					CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/def",
+					BasePath:       "orders",
+				},
+			},
+		})
+		return err
+	})
+
+	if domains := mocks.ByType("aws:apigateway/domainName:DomainName"); len(domains) != 2 {
+		t.Fatalf("expected 2 domain names, got %d", len(domains))
+	}
+}
+
+func TestEdgeDomainUsesEdgeCertificateField(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomain: &CustomDomainConfig{
+				DomainName:     "api.example.com",
+				CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+			},
+		})
+		return err
+	})
+
+	domain := mocks.ByType("aws:apigateway/domainName:DomainName")[0]
+	if got := domain.Inputs["certificateArn"].StringValue(); got != "arn:aws:acm:us-east-1:123456789012:certificate/abc" {
+		t.Errorf("certificateArn = %s, want the edge certificate", got)
+	}
+	if _, ok := domain.Inputs["regionalCertificateArn"]; ok {
+		t.Error("regionalCertificateArn set on an edge-optimized domain")
+	}
+}
+
+func TestRegionalDomainPrefersRegionalCertificate(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:  "dev",
+			EndpointType: "REGIONAL",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomain: &CustomDomainConfig{
+				DomainName:             "api.example.com",
+				CertificateArn:         "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+				RegionalCertificateArn: "arn:aws:acm:eu-west-1:123456789012:certificate/def",
+			},
+		})
+		return err
+	})
+
+	domain := mocks.ByType("aws:apigateway/domainName:DomainName")[0]
+	if got := domain.Inputs["regionalCertificateArn"].StringValue(); got != "arn:aws:acm:eu-west-1:123456789012:certificate/def" {
+		t.Errorf("regionalCertificateArn = %s, want the regional certificate", got)
+	}
+	if _, ok := domain.Inputs["certificateArn"]; ok {
+		t.Error("certificateArn set on a regional domain")
+	}
+}
+
+func TestEdgeDomainRejectsNonUsEast1Certificate(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			CustomDomain: &CustomDomainConfig{
+				DomainName:     "api.example.com",
+				CertificateArn: "arn:aws:acm:eu-west-1:123456789012:certificate/abc",
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected an edge domain with a non-us-east-1 certificate to be rejected")
+	}
+}
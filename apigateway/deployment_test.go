@@ -0,0 +1,42 @@
+package apigateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestDeploymentDependsOnMethodsAndIntegrations(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+				{Path: "/users", Method: "POST", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	deployments := mocks.ByType("aws:apigateway/deployment:Deployment")
+	if len(deployments) != 1 {
+		t.Fatalf("expected 1 deployment, got %d", len(deployments))
+	}
+	var methods, integrations int
+	for _, urn := range deployments[0].RegisterRPC.GetDependencies() {
+		if strings.Contains(urn, "apigateway/method:Method") {
+			methods++
+		}
+		if strings.Contains(urn, "apigateway/integration:Integration") {
+			integrations++
+		}
+	}
+	if methods != 2 {
+		t.Errorf("deployment depends on %d methods, want 2", methods)
+	}
+	if integrations != 2 {
+		t.Errorf("deployment depends on %d integrations, want 2", integrations)
+	}
+}
@@ -0,0 +1,67 @@
+package apigateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const restAPIToken = "aws:apigateway/restApi:RestApi"
+
+func TestEndpointTypes(t *testing.T) {
+	cases := []struct {
+		name           string
+		endpointType   string
+		vpcEndpointIds []string
+		wantType       string
+	}{
+		{"default is EDGE", "", nil, "EDGE"},
+		{"regional", "REGIONAL", nil, "REGIONAL"},
+		{"private with vpc endpoints", "PRIVATE", []string{"vpce-123"}, "PRIVATE"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mocks := runTest(t, func(ctx *pulumi.Context) error {
+				fn := newTestFunction(t, ctx, "handler")
+				_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+					Environment:    "dev",
+					EndpointType:   tc.endpointType,
+					VPCEndpointIds: tc.vpcEndpointIds,
+					Endpoints: []EndpointConfig{
+						{Path: "/users", Method: "GET", LambdaFunction: fn},
+					},
+				})
+				return err
+			})
+
+			apis := mocks.ByType(restAPIToken)
+			if len(apis) != 1 {
+				t.Fatalf("expected 1 REST API, got %d", len(apis))
+			}
+			endpointConfig := apis[0].Inputs["endpointConfiguration"].ObjectValue()
+			if got := endpointConfig["types"].StringValue(); got != tc.wantType {
+				t.Errorf("endpoint type = %s, want %s", got, tc.wantType)
+			}
+			if len(tc.vpcEndpointIds) > 0 {
+				ids := endpointConfig["vpcEndpointIds"].ArrayValue()
+				if len(ids) != len(tc.vpcEndpointIds) {
+					t.Errorf("vpcEndpointIds = %v, want %v", ids, tc.vpcEndpointIds)
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidEndpointTypeRejected(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:  "dev",
+			EndpointType: "GLOBAL",
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil || !strings.Contains(err.Error(), "EndpointType") {
+		t.Fatalf("expected endpoint type error, got %v", err)
+	}
+}
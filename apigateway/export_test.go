@@ -0,0 +1,39 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestExportOpenAPITargetsDeployedStage(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		api, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		ctx.Export("openapi", api.ExportOpenAPI(ctx))
+		return nil
+	})
+
+	calls := mocks.CallsByToken("aws:apigateway/getExport:getExport")
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 getExport call, got %d", len(calls))
+	}
+	args := calls[0].Args
+	if got := args["restApiId"].StringValue(); got != "api-id" {
+		t.Errorf("restApiId = %s, want api-id", got)
+	}
+	if got := args["stageName"].StringValue(); got != "dev" {
+		t.Errorf("stageName = %s, want dev", got)
+	}
+	if got := args["exportType"].StringValue(); got != "oas30" {
+		t.Errorf("exportType = %s, want oas30", got)
+	}
+}
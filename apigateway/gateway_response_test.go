@@ -0,0 +1,73 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const gatewayResponseToken = "aws:apigateway/response:Response"
+
+func TestCORSDefaultGatewayResponses(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			EnableCORS:  true,
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	responses := mocks.ByType(gatewayResponseToken)
+	if len(responses) != 2 {
+		t.Fatalf("expected DEFAULT_4XX and DEFAULT_5XX responses, got %d", len(responses))
+	}
+	var found bool
+	for _, r := range responses {
+		if r.Inputs["responseType"].StringValue() != "DEFAULT_4XX" {
+			continue
+		}
+		found = true
+		params := r.Inputs["responseParameters"].ObjectValue()
+		if got := params["gatewayresponse.header.Access-Control-Allow-Origin"].StringValue(); got != "'*'" {
+			t.Errorf("DEFAULT_4XX allow-origin = %s, want '*'", got)
+		}
+	}
+	if !found {
+		t.Error("DEFAULT_4XX gateway response not created")
+	}
+}
+
+func TestCustomGatewayResponse(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			GatewayResponses: []GatewayResponseConfig{
+				{
+					ResponseType:      "UNAUTHORIZED",
+					StatusCode:        "401",
+					ResponseTemplates: map[string]string{"application/json": `{"message":"no token"}`},
+				},
+			},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	responses := mocks.ByType(gatewayResponseToken)
+	if len(responses) != 1 {
+		t.Fatalf("expected 1 gateway response, got %d", len(responses))
+	}
+	if got := responses[0].Inputs["responseType"].StringValue(); got != "UNAUTHORIZED" {
+		t.Errorf("responseType = %s, want UNAUTHORIZED", got)
+	}
+	if got := responses[0].Inputs["statusCode"].StringValue(); got != "401" {
+		t.Errorf("statusCode = %s, want 401", got)
+	}
+}
@@ -0,0 +1,113 @@
+package apigateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestHTTPModeBuildsV2Resources(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			APIType:     "HTTP",
+			Endpoints: []EndpointConfig{
+				{Path: "/users/{id}", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	if apis := mocks.ByType("aws:apigatewayv2/api:Api"); len(apis) != 1 {
+		t.Fatalf("expected 1 v2 API, got %d", len(apis))
+	}
+	routes := mocks.ByType("aws:apigatewayv2/route:Route")
+	if len(routes) != 1 {
+		t.Fatalf("expected 1 route, got %d", len(routes))
+	}
+	if key := routes[0].Inputs["routeKey"].StringValue(); key != "GET /users/{id}" {
+		t.Errorf("routeKey = %q, want %q", key, "GET /users/{id}")
+	}
+	integrations := mocks.ByType("aws:apigatewayv2/integration:Integration")
+	if len(integrations) != 1 {
+		t.Fatalf("expected 1 integration, got %d", len(integrations))
+	}
+	if typ := integrations[0].Inputs["integrationType"].StringValue(); typ != "AWS_PROXY" {
+		t.Errorf("integrationType = %q, want AWS_PROXY", typ)
+	}
+	if v1 := mocks.ByType("aws:apigateway/restApi:RestApi"); len(v1) != 0 {
+		t.Errorf("REST API resources created in HTTP mode: %d", len(v1))
+	}
+}
+
+func TestRESTModeIsDefault(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	if v1 := mocks.ByType("aws:apigateway/restApi:RestApi"); len(v1) != 1 {
+		t.Fatalf("expected 1 REST API, got %d", len(v1))
+	}
+	if v2 := mocks.ByType("aws:apigatewayv2/api:Api"); len(v2) != 0 {
+		t.Errorf("v2 API resources created in REST mode: %d", len(v2))
+	}
+}
+
+func TestHTTPModeRejectsAPIKeys(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:   "dev",
+			APIType:       "HTTP",
+			RequireAPIKey: true,
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil || !strings.Contains(err.Error(), "APIType REST") {
+		t.Fatalf("expected API key rejection error, got %v", err)
+	}
+}
+
+func TestHTTPModeRejectsNonLambdaIntegrations(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			APIType:     "HTTP",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", IntegrationType: "HTTP_PROXY", TargetURI: "https://backend.example.com"},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil || !strings.Contains(err.Error(), "AWS_PROXY") {
+		t.Fatalf("expected HTTP_PROXY endpoint rejection error, got %v", err)
+	}
+}
+
+func TestHTTPModeRequiresLambdaFunction(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			APIType:     "HTTP",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET"},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil || !strings.Contains(err.Error(), "LambdaFunction") {
+		t.Fatalf("expected missing LambdaFunction error, got %v", err)
+	}
+}
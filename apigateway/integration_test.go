@@ -0,0 +1,92 @@
+package apigateway
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const integrationToken = "aws:apigateway/integration:Integration"
+
+func TestHTTPProxyIntegration(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{
+					Path:            "/external",
+					Method:          "GET",
+					IntegrationType: "HTTP_PROXY",
+					TargetURI:       "https://backend.example.com/external",
+				},
+			},
+		})
+		return err
+	})
+
+	integrations := mocks.ByType(integrationToken)
+	if len(integrations) != 1 {
+		t.Fatalf("expected 1 integration, got %d", len(integrations))
+	}
+	if got := integrations[0].Inputs["type"].StringValue(); got != "HTTP_PROXY" {
+		t.Errorf("integration type = %s, want HTTP_PROXY", got)
+	}
+	if got := integrations[0].Inputs["uri"].StringValue(); got != "https://backend.example.com/external" {
+		t.Errorf("uri = %s, want backend URL", got)
+	}
+	if perms := mocks.ByType("aws:lambda/permission:Permission"); len(perms) != 0 {
+		t.Errorf("expected no lambda permissions, got %d", len(perms))
+	}
+}
+
+func TestAWSProxyRequiresLambda(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET"},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil || !strings.Contains(err.Error(), "AWS_PROXY") {
+		t.Fatalf("expected AWS_PROXY validation error, got %v", err)
+	}
+}
+
+func TestIntegrationTimeoutPassedThrough(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn, TimeoutMilliseconds: 5000},
+			},
+		})
+		return err
+	})
+
+	integration := mocks.ByType(integrationToken)[0]
+	if got := integration.Inputs["timeoutMilliseconds"].NumberValue(); got != 5000 {
+		t.Errorf("timeoutMilliseconds = %v, want 5000", got)
+	}
+}
+
+func TestIntegrationTimeoutOutOfRange(t *testing.T) {
+	for _, timeout := range []int{10, 30000} {
+		err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+			fn := newTestFunction(t, ctx, "handler")
+			_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+				Environment: "dev",
+				Endpoints: []EndpointConfig{
+					{Path: "/users", Method: "GET", LambdaFunction: fn, TimeoutMilliseconds: timeout},
+				},
+			})
+			return err
+		}, pulumi.WithMocks("project", "stack", &testMocks{}))
+		if err == nil || !strings.Contains(err.Error(), "TimeoutMilliseconds") {
+			t.Errorf("timeout %d: expected range error, got %v", timeout, err)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/lambda"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/internal/testmocks"
+)
+
+// testMocks wraps the shared recorder and synthesizes the invoke ARN
+// the component reads off Lambda functions.
+type testMocks struct {
+	testmocks.Recorder
+}
+
+func (m *testMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, outputs, err := m.Recorder.NewResource(args)
+	if args.TypeToken == "aws:lambda/function:Function" {
+		outputs["invokeArn"] = resource.NewStringProperty(
+			"arn:aws:apigateway:us-east-1:lambda:path/2015-03-31/functions/" + args.Name + "/invocations")
+	}
+	return id, outputs, err
+}
+
+// runTest executes a Pulumi program against the mock monitor and fails
+// the test if the program errors.
+func runTest(t *testing.T, program pulumi.RunFunc) *testMocks {
+	t.Helper()
+	mocks := &testMocks{}
+	testmocks.Run(t, mocks, program)
+	return mocks
+}
+
+// newTestFunction creates a minimal Lambda function for wiring endpoints
+// in tests.
+func newTestFunction(t *testing.T, ctx *pulumi.Context, name string) *lambda.Function {
+	t.Helper()
+	fn, err := lambda.NewFunction(ctx, name, &lambda.FunctionArgs{
+		Role:    pulumi.String("arn:aws:iam::123456789012:role/test"),
+		Runtime: pulumi.String("go1.x"),
+		Handler: pulumi.String("main"),
+	})
+	if err != nil {
+		t.Fatalf("creating test function: %v", err)
+	}
+	return fn
+}
@@ -0,0 +1,74 @@
+package apigateway
+
+import (
+	"strings"
+	"testing"
+
+	awslambda "github.com/pulumi/pulumi-aws/sdk/v6/go/aws/lambda"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const testOpenAPISpec = `{
+  "openapi": "3.0.1",
+  "info": {"title": "orders", "version": "1.0"},
+  "paths": {
+    "/orders": {
+      "get": {
+        "x-amazon-apigateway-integration": {
+          "type": "aws_proxy",
+          "httpMethod": "POST",
+          "uri": "${lambda:orders}"
+        }
+      }
+    }
+  }
+}`
+
+func TestOpenAPIBodySubstitutesLambdaArns(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "orders-handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			OpenAPIBody: testOpenAPISpec,
+			OpenAPIFunctions: map[string]*awslambda.Function{
+				"orders": fn,
+			},
+		})
+		return err
+	})
+
+	api := mocks.ByType("aws:apigateway/restApi:RestApi")[0]
+	body := api.Inputs["body"].StringValue()
+	if strings.Contains(body, "${lambda:") {
+		t.Errorf("body still contains an unsubstituted placeholder:\n%s", body)
+	}
+	if !strings.Contains(body, "functions/orders-handler/invocations") {
+		t.Errorf("body does not reference the orders function invoke ARN:\n%s", body)
+	}
+
+	// The spec replaces the imperative endpoint loop entirely.
+	if methods := mocks.ByType("aws:apigateway/method:Method"); len(methods) != 0 {
+		t.Errorf("expected no imperative methods, got %d", len(methods))
+	}
+	// The function still needs an invoke permission.
+	if perms := mocks.ByType("aws:lambda/permission:Permission"); len(perms) != 1 {
+		t.Errorf("expected 1 lambda permission, got %d", len(perms))
+	}
+}
+
+func TestOpenAPIBodyRejectsEndpoints(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			OpenAPIBody: testOpenAPISpec,
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected OpenAPIBody combined with Endpoints to be rejected")
+	}
+}
@@ -0,0 +1,54 @@
+package apigateway
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Option mutates an APIGatewayConfig. Options are applied in order,
+// so later options override earlier ones.
+type Option func(*APIGatewayConfig)
+
+// WithEnvironment sets the environment tag, e.g. "dev" or "prod".
+func WithEnvironment(environment string) Option {
+	return func(c *APIGatewayConfig) { c.Environment = environment }
+}
+
+// WithEndpoint appends one endpoint to the API.
+func WithEndpoint(endpoint EndpointConfig) Option {
+	return func(c *APIGatewayConfig) { c.Endpoints = append(c.Endpoints, endpoint) }
+}
+
+// WithCORS enables CORS, optionally customizing the preflight
+// headers. A nil cors uses the permissive defaults.
+func WithCORS(cors *CORSConfig) Option {
+	return func(c *APIGatewayConfig) {
+		c.EnableCORS = true
+		c.CORS = cors
+	}
+}
+
+// WithStage appends a deployment stage.
+func WithStage(stage StageConfig) Option {
+	return func(c *APIGatewayConfig) { c.Stages = append(c.Stages, stage) }
+}
+
+// WithTags merges tags into the resource tag set.
+func WithTags(tags map[string]string) Option {
+	return func(c *APIGatewayConfig) {
+		if c.Tags == nil {
+			c.Tags = map[string]string{}
+		}
+		for k, v := range tags {
+			c.Tags[k] = v
+		}
+	}
+}
+
+// NewAPIGatewayWithOptions builds an APIGatewayConfig from functional
+// options and creates the API. Equivalent to NewAPIGateway with a
+// hand-built config.
+func NewAPIGatewayWithOptions(ctx *pulumi.Context, name string, options ...Option) (*APIGateway, error) {
+	config := &APIGatewayConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return NewAPIGateway(ctx, name, config)
+}
@@ -0,0 +1,39 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestOptionsBuildEquivalentConfig(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGatewayWithOptions(ctx, "api",
+			WithEnvironment("dev"),
+			WithCORS(nil),
+			WithEndpoint(EndpointConfig{Path: "/items", Method: "GET", LambdaFunction: fn}),
+			WithTags(map[string]string{"Team": "platform"}),
+		)
+		return err
+	})
+
+	apis := mocks.ByType("aws:apigateway/restApi:RestApi")
+	if len(apis) != 1 {
+		t.Fatalf("expected 1 REST API, got %d", len(apis))
+	}
+	if got := apis[0].Inputs["tags"].ObjectValue()["Team"].StringValue(); got != "platform" {
+		t.Errorf("Team tag = %s, want platform", got)
+	}
+	// WithCORS adds the OPTIONS mock integration alongside the GET.
+	methods := mocks.ByType("aws:apigateway/method:Method")
+	foundOptions := false
+	for _, m := range methods {
+		if m.Inputs["httpMethod"].StringValue() == "OPTIONS" {
+			foundOptions = true
+		}
+	}
+	if !foundOptions {
+		t.Error("WithCORS did not add an OPTIONS method")
+	}
+}
@@ -0,0 +1,40 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestGreedyProxyWithANYMethod(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/{proxy+}", Method: "ANY", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	resources := mocks.ByType("aws:apigateway/resource:Resource")
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resources))
+	}
+	if got := resources[0].Inputs["pathPart"].StringValue(); got != "{proxy+}" {
+		t.Errorf("pathPart = %s, want {proxy+}", got)
+	}
+
+	methods := mocks.ByType("aws:apigateway/method:Method")
+	if len(methods) != 1 {
+		t.Fatalf("expected 1 method, got %d", len(methods))
+	}
+	if got := methods[0].Inputs["httpMethod"].StringValue(); got != "ANY" {
+		t.Errorf("httpMethod = %s, want ANY", got)
+	}
+	params := methods[0].Inputs["requestParameters"].ObjectValue()
+	if !params["method.request.path.proxy"].BoolValue() {
+		t.Error("method.request.path.proxy not declared on proxy method")
+	}
+}
@@ -0,0 +1,84 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestMultipleStagesShareDeployment(t *testing.T) {
+	var gw *APIGateway
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		var err error
+		gw, err = NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Stages: []StageConfig{
+				{Name: "staging", EnableXRayTracing: true},
+				{Name: "prod", Throttle: &ThrottleConfig{BurstLimit: 500, RateLimit: 200}},
+			},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	stages := mocks.ByType(stageToken)
+	if len(stages) != 2 {
+		t.Fatalf("expected 2 stages, got %d", len(stages))
+	}
+	names := map[string]bool{}
+	for _, s := range stages {
+		names[s.Inputs["stageName"].StringValue()] = true
+	}
+	if !names["staging"] || !names["prod"] {
+		t.Errorf("stage names = %v, want staging and prod", names)
+	}
+	if deployments := mocks.ByType("aws:apigateway/deployment:Deployment"); len(deployments) != 1 {
+		t.Errorf("expected stages to share 1 deployment, got %d", len(deployments))
+	}
+
+	settings := mocks.ByType(methodSettingsToken)
+	if len(settings) != 1 {
+		t.Fatalf("expected 1 method settings (prod throttle), got %d", len(settings))
+	}
+
+	if len(gw.StageURLs) != 2 {
+		t.Errorf("StageURLs has %d entries, want 2", len(gw.StageURLs))
+	}
+	for _, stageName := range []string{"staging", "prod"} {
+		if _, ok := gw.StageURLs[stageName]; !ok {
+			t.Errorf("StageURLs missing %s", stageName)
+		}
+	}
+}
+
+func TestStageVariablesPropagate(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment:    "dev",
+			StageVariables: map[string]string{"lambdaAlias": "live"},
+			Endpoints: []EndpointConfig{
+				{
+					Path:            "/users",
+					Method:          "GET",
+					IntegrationType: "HTTP_PROXY",
+					TargetURI:       "https://${stageVariables.backendHost}/users",
+				},
+			},
+		})
+		return err
+	})
+
+	stage := mocks.ByType(stageToken)[0]
+	variables := stage.Inputs["variables"].ObjectValue()
+	if got := variables["lambdaAlias"].StringValue(); got != "live" {
+		t.Errorf("stage variable lambdaAlias = %s, want live", got)
+	}
+
+	integration := mocks.ByType(integrationToken)[0]
+	if got := integration.Inputs["uri"].StringValue(); got != "https://${stageVariables.backendHost}/users" {
+		t.Errorf("templated URI not preserved: %s", got)
+	}
+}
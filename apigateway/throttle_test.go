@@ -0,0 +1,60 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const methodSettingsToken = "aws:apigateway/methodSettings:MethodSettings"
+
+func TestStageDefaultThrottle(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Throttle:    &ThrottleConfig{BurstLimit: 100, RateLimit: 50},
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	settings := mocks.ByType(methodSettingsToken)
+	if len(settings) != 1 {
+		t.Fatalf("expected 1 method settings resource, got %d", len(settings))
+	}
+	if got := settings[0].Inputs["methodPath"].StringValue(); got != "*/*" {
+		t.Errorf("methodPath = %s, want */*", got)
+	}
+	values := settings[0].Inputs["settings"].ObjectValue()
+	if got := values["throttlingBurstLimit"].NumberValue(); got != 100 {
+		t.Errorf("throttlingBurstLimit = %v, want 100", got)
+	}
+	if got := values["throttlingRateLimit"].NumberValue(); got != 50 {
+		t.Errorf("throttlingRateLimit = %v, want 50", got)
+	}
+}
+
+func TestPerMethodThrottle(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users/{id}", Method: "GET", LambdaFunction: fn, ThrottleBurstLimit: 10, ThrottleRateLimit: 5},
+				{Path: "/health", Method: "GET", LambdaFunction: fn},
+			},
+		})
+		return err
+	})
+
+	settings := mocks.ByType(methodSettingsToken)
+	if len(settings) != 1 {
+		t.Fatalf("expected 1 method settings resource, got %d", len(settings))
+	}
+	if got := settings[0].Inputs["methodPath"].StringValue(); got != "users/{id}/GET" {
+		t.Errorf("methodPath = %s, want users/{id}/GET", got)
+	}
+}
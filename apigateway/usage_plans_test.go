@@ -0,0 +1,69 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestNamedUsagePlansWithDistinctQuotas(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			ApiKeys: []ApiKeyConfig{
+				{Name: "free-tenant"},
+				{Name: "premium-tenant"},
+			},
+			UsagePlans: []UsagePlanConfig{
+				{Name: "free", Quota: &QuotaConfig{Limit: 1000, Period: "DAY"}, KeyNames: []string{"free-tenant"}},
+				{Name: "premium", Quota: &QuotaConfig{Limit: 100000, Period: "DAY"}, KeyNames: []string{"premium-tenant"}},
+			},
+		})
+		return err
+	})
+
+	if keys := mocks.ByType("aws:apigateway/apiKey:ApiKey"); len(keys) != 2 {
+		t.Fatalf("expected 2 API keys, got %d", len(keys))
+	}
+	plans := mocks.ByType("aws:apigateway/usagePlan:UsagePlan")
+	if len(plans) != 2 {
+		t.Fatalf("expected 2 usage plans, got %d", len(plans))
+	}
+	limits := map[string]float64{}
+	for _, plan := range plans {
+		quota := plan.Inputs["quotaSettings"].ObjectValue()
+		limits[plan.Inputs["name"].StringValue()] = quota["limit"].NumberValue()
+	}
+	if limits["api-free"] != 1000 {
+		t.Errorf("free plan limit = %v, want 1000", limits["api-free"])
+	}
+	if limits["api-premium"] != 100000 {
+		t.Errorf("premium plan limit = %v, want 100000", limits["api-premium"])
+	}
+	if links := mocks.ByType("aws:apigateway/usagePlanKey:UsagePlanKey"); len(links) != 2 {
+		t.Errorf("expected 2 usage plan keys, got %d", len(links))
+	}
+}
+
+func TestUsagePlanRejectsUnknownKeyName(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{Path: "/users", Method: "GET", LambdaFunction: fn},
+			},
+			UsagePlans: []UsagePlanConfig{
+				{Name: "free", KeyNames: []string{"missing"}},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected an unmatched usage plan key name to be rejected")
+	}
+}
@@ -0,0 +1,38 @@
+package apigateway
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMissingEnvironment(t *testing.T) {
+	config := &APIGatewayConfig{}
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), "Environment is required") {
+		t.Errorf("Validate() = %v, want Environment is required", err)
+	}
+}
+
+func TestValidateBadQuotaPeriod(t *testing.T) {
+	config := &APIGatewayConfig{
+		Environment: "dev",
+		Quota:       &QuotaConfig{Limit: 1000, Period: "FORTNIGHT"},
+	}
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), `invalid QuotaConfig.Period "FORTNIGHT"`) {
+		t.Errorf("Validate() = %v, want invalid QuotaConfig.Period", err)
+	}
+}
+
+func TestValidateAggregatesErrors(t *testing.T) {
+	config := &APIGatewayConfig{Quota: &QuotaConfig{Period: "YEAR"}}
+	err := config.Validate()
+	if err == nil {
+		t.Fatal("Validate() = nil, want both errors")
+	}
+	for _, want := range []string{"Environment is required", "QuotaConfig.Period"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Validate() = %v, missing %q", err, want)
+		}
+	}
+}
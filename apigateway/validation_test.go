@@ -0,0 +1,55 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const userSchema = `{"type":"object","required":["name"],"properties":{"name":{"type":"string"}}}`
+
+func TestRequestSchemaCreatesModel(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		fn := newTestFunction(t, ctx, "handler")
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{
+					Path:                "/users",
+					Method:              "POST",
+					LambdaFunction:      fn,
+					RequestSchemas:      map[string]string{"application/json": userSchema},
+					ValidateRequestBody: true,
+				},
+			},
+		})
+		return err
+	})
+
+	models := mocks.ByType("aws:apigateway/model:Model")
+	if len(models) != 1 {
+		t.Fatalf("expected 1 model, got %d", len(models))
+	}
+	if got := models[0].Inputs["schema"].StringValue(); got != userSchema {
+		t.Errorf("model schema = %s, want %s", got, userSchema)
+	}
+	if got := models[0].Inputs["contentType"].StringValue(); got != "application/json" {
+		t.Errorf("model contentType = %s, want application/json", got)
+	}
+
+	validators := mocks.ByType("aws:apigateway/requestValidator:RequestValidator")
+	if len(validators) != 1 {
+		t.Fatalf("expected 1 request validator, got %d", len(validators))
+	}
+	if !validators[0].Inputs["validateRequestBody"].BoolValue() {
+		t.Error("validateRequestBody not set on validator")
+	}
+
+	method := mocks.ByType("aws:apigateway/method:Method")[0]
+	if method.Inputs["requestValidatorId"].IsNull() {
+		t.Error("method does not reference the request validator")
+	}
+	if method.Inputs["requestModels"].IsNull() {
+		t.Error("method does not reference the generated model")
+	}
+}
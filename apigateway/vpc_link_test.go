@@ -0,0 +1,94 @@
+package apigateway
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const vpcLinkToken = "aws:apigateway/vpcLink:VpcLink"
+
+func TestVpcLinkCreated(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			VpcLinks: []VpcLinkConfig{
+				{Name: "internal", TargetArn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/internal/abc"},
+			},
+			Endpoints: []EndpointConfig{
+				{
+					Path:            "/orders",
+					Method:          "GET",
+					IntegrationType: "HTTP_PROXY",
+					TargetURI:       "http://internal.example.com/orders",
+					ConnectionType:  "VPC_LINK",
+					VpcLinkName:     "internal",
+				},
+			},
+		})
+		return err
+	})
+
+	links := mocks.ByType(vpcLinkToken)
+	if len(links) != 1 {
+		t.Fatalf("expected 1 VPC link, got %d", len(links))
+	}
+	if got := links[0].Inputs["name"].StringValue(); got != "internal" {
+		t.Errorf("link name = %s, want internal", got)
+	}
+	if got := links[0].Inputs["targetArn"].StringValue(); got == "" {
+		t.Error("link targetArn not set")
+	}
+}
+
+func TestVpcLinkIntegrationConnection(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			VpcLinks: []VpcLinkConfig{
+				{Name: "internal", TargetArn: "arn:aws:elasticloadbalancing:us-east-1:123456789012:loadbalancer/net/internal/abc"},
+			},
+			Endpoints: []EndpointConfig{
+				{
+					Path:            "/orders",
+					Method:          "GET",
+					IntegrationType: "HTTP_PROXY",
+					TargetURI:       "http://internal.example.com/orders",
+					ConnectionType:  "VPC_LINK",
+					VpcLinkName:     "internal",
+				},
+			},
+		})
+		return err
+	})
+
+	integration := mocks.ByType("aws:apigateway/integration:Integration")[0]
+	if got := integration.Inputs["connectionType"].StringValue(); got != "VPC_LINK" {
+		t.Errorf("connectionType = %s, want VPC_LINK", got)
+	}
+	if got := integration.Inputs["connectionId"].StringValue(); got == "" {
+		t.Error("connectionId not set")
+	}
+}
+
+func TestVpcLinkNameMustMatch(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewAPIGateway(ctx, "api", &APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []EndpointConfig{
+				{
+					Path:            "/orders",
+					Method:          "GET",
+					IntegrationType: "HTTP_PROXY",
+					TargetURI:       "http://internal.example.com/orders",
+					ConnectionType:  "VPC_LINK",
+					VpcLinkName:     "missing",
+				},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected an unmatched VpcLinkName to be rejected")
+	}
+}
@@ -0,0 +1,72 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestTwoOriginsWithAPIBehavior(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{OriginId: "assets", DomainName: "assets.s3.us-east-1.amazonaws.com", OriginType: "s3"},
+				{OriginId: "api", DomainName: "api.internal.example.com"},
+			},
+			OrderedCacheBehaviors: []CacheBehaviorConfig{
+				{
+					PathPattern:    "/api/*",
+					TargetOriginId: "api",
+					AllowedMethods: []string{"GET", "HEAD", "OPTIONS", "PUT", "POST", "PATCH", "DELETE"},
+					MaxTTLSeconds:  1,
+				},
+			},
+		})
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	origins := distribution.Inputs["origins"].ArrayValue()
+	if len(origins) != 2 {
+		t.Fatalf("expected 2 origins, got %d", len(origins))
+	}
+
+	behaviors := distribution.Inputs["orderedCacheBehaviors"].ArrayValue()
+	if len(behaviors) != 1 {
+		t.Fatalf("expected 1 ordered cache behavior, got %d", len(behaviors))
+	}
+	behavior := behaviors[0].ObjectValue()
+	if got := behavior["pathPattern"].StringValue(); got != "/api/*" {
+		t.Errorf("pathPattern = %s, want /api/*", got)
+	}
+	if got := behavior["targetOriginId"].StringValue(); got != "api" {
+		t.Errorf("targetOriginId = %s, want api", got)
+	}
+	if got := len(behavior["allowedMethods"].ArrayValue()); got != 7 {
+		t.Errorf("allowedMethods count = %d, want 7", got)
+	}
+
+	defaultBehavior := distribution.Inputs["defaultCacheBehavior"].ObjectValue()
+	if got := defaultBehavior["targetOriginId"].StringValue(); got != "assets" {
+		t.Errorf("default behavior target = %s, want the first origin", got)
+	}
+}
+
+func TestBehaviorRejectsUnknownOrigin(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{OriginId: "assets", DomainName: "assets.s3.us-east-1.amazonaws.com", OriginType: "s3"},
+			},
+			OrderedCacheBehaviors: []CacheBehaviorConfig{
+				{PathPattern: "/api/*", TargetOriginId: "missing"},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected behavior with unknown origin id to be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestCachePolicyReplacesForwardedValues(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.CachePolicyId = CachePolicyCachingOptimized
+		config.OriginRequestPolicyId = OriginRequestPolicyAllViewer
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	behavior := distribution.Inputs["defaultCacheBehavior"].ObjectValue()
+	if got := behavior["cachePolicyId"].StringValue(); got != CachePolicyCachingOptimized {
+		t.Errorf("cachePolicyId = %s, want CachingOptimized", got)
+	}
+	if got := behavior["originRequestPolicyId"].StringValue(); got != OriginRequestPolicyAllViewer {
+		t.Errorf("originRequestPolicyId = %s, want AllViewer", got)
+	}
+	if !behavior["forwardedValues"].IsNull() {
+		t.Error("forwardedValues must be omitted when a cache policy is set")
+	}
+}
+
+func TestLegacyForwardedValuesByDefault(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", testConfig())
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	behavior := distribution.Inputs["defaultCacheBehavior"].ObjectValue()
+	if behavior["forwardedValues"].IsNull() {
+		t.Error("forwardedValues missing without a cache policy")
+	}
+	if !behavior["cachePolicyId"].IsNull() {
+		t.Error("cachePolicyId set without being configured")
+	}
+}
+
+func TestOriginRequestPolicyRequiresCachePolicy(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.OriginRequestPolicyId = OriginRequestPolicyAllViewer
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected origin request policy without cache policy to be rejected")
+	}
+}
@@ -0,0 +1,84 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestDefaultCertificateWithoutAliases(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", testConfig())
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	certificate := distribution.Inputs["viewerCertificate"].ObjectValue()
+	if !certificate["cloudfrontDefaultCertificate"].BoolValue() {
+		t.Error("default certificate not enabled without aliases")
+	}
+	if !certificate["acmCertificateArn"].IsNull() {
+		t.Error("ACM certificate ARN set despite no certificate being configured")
+	}
+}
+
+func TestSuppliedCertificate(t *testing.T) {
+	certArn := "arn:aws:acm:us-east-1:123456789012:certificate/abc-123"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Aliases = []string{"cdn.example.com"}
+		config.CertificateArn = certArn
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	certificate := distribution.Inputs["viewerCertificate"].ObjectValue()
+	if got := certificate["acmCertificateArn"].StringValue(); got != certArn {
+		t.Errorf("acmCertificateArn = %s, want %s", got, certArn)
+	}
+	if got := certificate["sslSupportMethod"].StringValue(); got != "sni-only" {
+		t.Errorf("sslSupportMethod = %s, want sni-only", got)
+	}
+}
+
+func TestAliasWithoutCertificateCreatesOne(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Aliases = []string{"cdn.example.com", "www.example.com"}
+		config.Route53ZoneId = "Z123456789"
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	certificates := mocks.ByType("aws:acm/certificate:Certificate")
+	if len(certificates) != 1 {
+		t.Fatalf("expected 1 certificate, got %d", len(certificates))
+	}
+	certificate := certificates[0]
+	if got := certificate.Inputs["domainName"].StringValue(); got != "cdn.example.com" {
+		t.Errorf("certificate domain = %s, want cdn.example.com", got)
+	}
+	if got := certificate.Inputs["validationMethod"].StringValue(); got != "DNS" {
+		t.Errorf("validationMethod = %s, want DNS", got)
+	}
+
+	// CloudFront only accepts certificates from us-east-1, so the cert
+	// must go through a provider pinned to that region.
+	regionPinned := false
+	for _, p := range mocks.ByType("pulumi:providers:aws") {
+		if p.Inputs["region"].StringValue() == "us-east-1" {
+			regionPinned = true
+		}
+	}
+	if !regionPinned {
+		t.Error("no us-east-1 provider registered for the certificate")
+	}
+
+	if validations := mocks.ByType("aws:acm/certificateValidation:CertificateValidation"); len(validations) != 1 {
+		t.Errorf("expected 1 certificate validation, got %d", len(validations))
+	}
+	if records := mocks.ByType("aws:route53/record:Record"); len(records) != 2 {
+		t.Errorf("expected 2 validation records, got %d", len(records))
+	}
+}
@@ -0,0 +1,1058 @@
+// Package cloudfront provides a reusable Pulumi component for
+// CloudFront distributions with sensible TLS and caching defaults.
+package cloudfront
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/acm"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/cloudfront"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/route53"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/s3"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/profiles"
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// Commonly used AWS managed cache policy ids.
+const (
+	// CachePolicyCachingOptimized is the managed CachingOptimized
+	// policy, recommended for S3 and static content origins.
+	CachePolicyCachingOptimized = "658327ea-f89d-4fab-a63d-7e88639e58f6"
+	// CachePolicyCachingDisabled is the managed CachingDisabled policy,
+	// recommended for dynamic API origins.
+	CachePolicyCachingDisabled = "4135ea2d-6df8-44a3-9df3-4b5a84be39ad"
+	// OriginRequestPolicyAllViewer is the managed AllViewer origin
+	// request policy, forwarding the full viewer request to the origin.
+	OriginRequestPolicyAllViewer = "216adef6-5c7f-47e4-b989-5492eafa07d3"
+)
+
+// OriginConfig describes one origin of a multi-origin distribution.
+type OriginConfig struct {
+	// OriginId uniquely names the origin within the distribution and is
+	// referenced by cache behaviors.
+	OriginId string
+	// DomainName is the origin to front.
+	DomainName string
+	// OriginDomain wires another component's output as the origin
+	// domain, e.g. a SecureBucket's regional domain name. Takes
+	// precedence over DomainName.
+	OriginDomain pulumi.StringInput
+	// OriginType is "custom" (default) or "s3".
+	OriginType string
+	// OriginProtocolPolicy is how CloudFront connects to a custom
+	// origin. Defaults to "https-only". Ignored for S3 origins.
+	OriginProtocolPolicy string
+	// OriginPath optionally prefixes every request to the origin.
+	OriginPath string
+	// CustomHeaders are sent with every request to the origin, e.g. a
+	// shared secret the origin uses to reject direct access.
+	CustomHeaders map[string]string
+	// OriginShield optionally adds a caching layer in front of the
+	// origin.
+	OriginShield *OriginShieldConfig
+	// ConnectionAttempts is how often CloudFront tries to connect to
+	// the origin, between 1 and 3. Zero keeps the default of 3.
+	ConnectionAttempts int
+	// ConnectionTimeoutSeconds is the per-attempt connection timeout,
+	// between 1 and 10. Zero keeps the default of 10.
+	ConnectionTimeoutSeconds int
+	// OriginReadTimeoutSeconds is how long CloudFront waits for a
+	// response from a custom origin, between 1 and 180. Zero keeps the
+	// default of 30. Ignored for S3 origins.
+	OriginReadTimeoutSeconds int
+	// OriginKeepaliveTimeoutSeconds is how long idle connections to a
+	// custom origin stay open, between 1 and 60. Zero keeps the default
+	// of 5. Ignored for S3 origins.
+	OriginKeepaliveTimeoutSeconds int
+}
+
+// OriginShieldConfig enables CloudFront Origin Shield for an origin.
+type OriginShieldConfig struct {
+	// Enabled turns on origin shield.
+	Enabled bool
+	// Region is the AWS region hosting the shield, usually the
+	// origin's region.
+	Region string
+}
+
+// OriginGroupConfig pairs a primary origin with a fallback that serves
+// requests when the primary returns a failover status code.
+type OriginGroupConfig struct {
+	// GroupId uniquely names the group and can be targeted by cache
+	// behaviors like an origin id.
+	GroupId string
+	// PrimaryOriginId and SecondaryOriginId reference entries in
+	// Origins.
+	PrimaryOriginId   string
+	SecondaryOriginId string
+	// FailoverStatusCodes are the primary's status codes that trigger
+	// failover, e.g. 502, 503, 504.
+	FailoverStatusCodes []int
+}
+
+// FunctionAssociationConfig attaches edge compute to a cache behavior.
+// Exactly one of FunctionArn (a CloudFront Function) or
+// LambdaFunctionArn (a qualified Lambda@Edge version ARN) must be set.
+type FunctionAssociationConfig struct {
+	// EventType is when the function runs: "viewer-request" or
+	// "viewer-response" for CloudFront Functions; Lambda@Edge also
+	// supports "origin-request" and "origin-response".
+	EventType string
+	// FunctionArn is a CloudFront Function ARN.
+	FunctionArn string
+	// LambdaFunctionArn is a qualified Lambda@Edge ARN including the
+	// version ($LATEST is not allowed by CloudFront).
+	LambdaFunctionArn string
+	// IncludeBody exposes the request body to Lambda@Edge.
+	IncludeBody bool
+}
+
+// validate checks the event-type/compute-type combination.
+func (a FunctionAssociationConfig) validate() error {
+	if (a.FunctionArn == "") == (a.LambdaFunctionArn == "") {
+		return fmt.Errorf("function association requires exactly one of FunctionArn or LambdaFunctionArn")
+	}
+	switch a.EventType {
+	case "viewer-request", "viewer-response":
+	case "origin-request", "origin-response":
+		if a.FunctionArn != "" {
+			return fmt.Errorf("CloudFront Functions only support viewer-request and viewer-response, got %q", a.EventType)
+		}
+	default:
+		return fmt.Errorf("invalid function association event type %q", a.EventType)
+	}
+	if a.IncludeBody && a.LambdaFunctionArn == "" {
+		return fmt.Errorf("IncludeBody is only supported for Lambda@Edge associations")
+	}
+	return nil
+}
+
+// CacheBehaviorConfig routes a path pattern to an origin with its own
+// caching settings.
+type CacheBehaviorConfig struct {
+	// PathPattern selects requests, e.g. "/api/*".
+	PathPattern string
+	// TargetOriginId references an entry in Origins.
+	TargetOriginId string
+	// ViewerProtocolPolicy defaults to "redirect-to-https".
+	ViewerProtocolPolicy string
+	// AllowedMethods defaults to GET, HEAD and OPTIONS.
+	AllowedMethods []string
+	// MinTTLSeconds, DefaultTTLSeconds and MaxTTLSeconds control
+	// caching for this behavior. Zero values use 0/86400/31536000.
+	MinTTLSeconds     int
+	DefaultTTLSeconds int
+	MaxTTLSeconds     int
+	// FunctionAssociations attach CloudFront Functions or Lambda@Edge
+	// to this behavior.
+	FunctionAssociations []FunctionAssociationConfig
+	// CachePolicyId selects a cache policy instead of the legacy
+	// ForwardedValues block. When set, the TTL fields are ignored in
+	// favor of the policy's settings.
+	CachePolicyId string
+	// OriginRequestPolicyId controls what is forwarded to the origin.
+	// Requires CachePolicyId.
+	OriginRequestPolicyId string
+	// ResponseHeadersPolicyId attaches a response headers policy.
+	ResponseHeadersPolicyId string
+	// TrustedKeyGroupIds restrict this behavior to requests carrying a
+	// URL or cookie signed with a key from one of these key groups.
+	TrustedKeyGroupIds []string
+}
+
+// ErrorResponseConfig rewrites an origin error into a custom response,
+// e.g. serving index.html with a 200 for SPA routes.
+type ErrorResponseConfig struct {
+	// ErrorCode is the origin HTTP status to intercept.
+	ErrorCode int
+	// ResponseCode is the status returned to the viewer. Zero passes
+	// the error through unchanged.
+	ResponseCode int
+	// ResponsePagePath is the path served instead, e.g. "/index.html".
+	ResponsePagePath string
+	// ErrorCachingMinTTLSeconds is how long the error is cached.
+	ErrorCachingMinTTLSeconds int
+}
+
+// SecurityHeadersConfig creates a response headers policy adding common
+// security headers to every response from the default cache behavior.
+type SecurityHeadersConfig struct {
+	// HSTSMaxAgeSeconds is the Strict-Transport-Security max-age.
+	// Defaults to 31536000 (one year).
+	HSTSMaxAgeSeconds int
+	// FrameOption is the X-Frame-Options value, "DENY" (default) or
+	// "SAMEORIGIN".
+	FrameOption string
+	// ReferrerPolicy defaults to "strict-origin-when-cross-origin".
+	ReferrerPolicy string
+	// ContentSecurityPolicy optionally sets a CSP header.
+	ContentSecurityPolicy string
+}
+
+// GeoRestrictionConfig limits which countries may access the
+// distribution.
+type GeoRestrictionConfig struct {
+	// RestrictionType is "whitelist", "blacklist" or "none".
+	RestrictionType string
+	// Locations lists ISO 3166-1 alpha-2 country codes. Required for
+	// whitelist and blacklist, must be empty for none.
+	Locations []string
+}
+
+// LoggingConfig captures access logs in an S3 bucket.
+type LoggingConfig struct {
+	// BucketDomainName is the log bucket's domain name, e.g.
+	// "logs.s3.amazonaws.com". Empty auto-creates a bucket configured
+	// for CloudFront log delivery.
+	BucketDomainName string
+	// Prefix is prepended to every log object key.
+	Prefix string
+	// IncludeCookies logs cookie values.
+	IncludeCookies bool
+}
+
+// CloudFrontConfig configures a CloudFrontDistribution component.
+type CloudFrontConfig struct {
+	// Environment tags every created resource (e.g. "dev", "prod").
+	Environment string
+	// OriginDomainName is the origin to front, e.g. an ALB DNS name or
+	// an S3 bucket regional domain name. Ignored when Origins is set.
+	OriginDomainName string
+	// Origins lists the origins of a multi-origin distribution. When
+	// empty a single origin is built from OriginDomainName.
+	Origins []OriginConfig
+	// OriginGroups define primary/fallback origin pairs that cache
+	// behaviors can target for failover.
+	OriginGroups []OriginGroupConfig
+	// OrderedCacheBehaviors route path patterns to specific origins.
+	// Requests matching none of them use the default cache behavior,
+	// which targets the first origin.
+	OrderedCacheBehaviors []CacheBehaviorConfig
+	// CustomErrorResponses rewrite origin errors before they reach the
+	// viewer.
+	CustomErrorResponses []ErrorResponseConfig
+	// FunctionAssociations attach CloudFront Functions or Lambda@Edge
+	// to the default cache behavior.
+	FunctionAssociations []FunctionAssociationConfig
+	// CachePolicyId selects a cache policy for the default behavior
+	// instead of the legacy ForwardedValues block. When set, the TTL
+	// fields are ignored in favor of the policy's settings.
+	CachePolicyId string
+	// OriginRequestPolicyId controls what the default behavior forwards
+	// to the origin. Requires CachePolicyId.
+	OriginRequestPolicyId string
+	// ResponseHeadersPolicyId attaches a response headers policy to the
+	// default behavior. Mutually exclusive with SecurityHeaders.
+	ResponseHeadersPolicyId string
+	// SecurityHeaders creates a response headers policy with common
+	// security headers and attaches it to the default behavior.
+	SecurityHeaders *SecurityHeadersConfig
+	// TrustedKeyGroupIds restrict the default behavior to requests
+	// carrying a signed URL or cookie from one of these key groups.
+	TrustedKeyGroupIds []string
+	// SigningPublicKeyPEM creates a public key and key group from the
+	// given PEM and trusts it on the default behavior, as an
+	// alternative to managing key groups out of band.
+	SigningPublicKeyPEM string
+	// WebAclArn attaches a WAFv2 web ACL (CLOUDFRONT scope) to the
+	// distribution, e.g. the ACLArn output of a waf.WebACL.
+	WebAclArn pulumi.StringInput
+	// Logging optionally captures access logs in an S3 bucket.
+	Logging *LoggingConfig
+	// GeoRestriction optionally limits access by viewer country.
+	GeoRestriction *GeoRestrictionConfig
+	// OriginType is "custom" (default) or "s3". S3 origins are accessed
+	// through an origin access control so the bucket can stay private.
+	OriginType string
+	// OriginProtocolPolicy is how CloudFront connects to a custom
+	// origin: "http-only", "https-only" or "match-viewer". Defaults to
+	// "https-only". Ignored for S3 origins.
+	OriginProtocolPolicy string
+	// ViewerProtocolPolicy defaults to "redirect-to-https".
+	ViewerProtocolPolicy string
+	// PriceClass defaults to the environment profile, then
+	// "PriceClass_100".
+	PriceClass string
+	// HttpVersion is "http2" (default) or "http2and3" to additionally
+	// enable HTTP/3.
+	HttpVersion string
+	// DefaultRootObject is served for requests to the root URL, e.g.
+	// "index.html".
+	DefaultRootObject string
+	// Aliases are alternate domain names for the distribution.
+	Aliases []string
+	// CertificateArn is an ACM certificate ARN in us-east-1; if not
+	// provided and Aliases are set, will create new cert.
+	CertificateArn string
+	// Route53ZoneId is the hosted zone for the aliases. When set, the
+	// auto-created certificate is validated through DNS records in this
+	// zone; without it the certificate waits for manual validation.
+	Route53ZoneId string
+	// MinTTLSeconds, DefaultTTLSeconds and MaxTTLSeconds control the
+	// default cache behavior. Zero values use 0/86400/31536000.
+	MinTTLSeconds     int
+	DefaultTTLSeconds int
+	MaxTTLSeconds     int
+	// ImportID adopts an existing distribution instead of creating a new
+	// one, via pulumi.Import. Child resources (origin access controls, policies) are
+	// still created and must not already exist.
+	ImportID string
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// CloudFrontDistribution is a CloudFront distribution fronting a single
+// origin.
+type CloudFrontDistribution struct {
+	pulumi.ResourceState
+
+	// Distribution is the underlying CloudFront distribution.
+	Distribution *cloudfront.Distribution
+	// DomainName is the *.cloudfront.net domain of the distribution.
+	DomainName pulumi.StringOutput
+	// OriginAccessControlId identifies the OAC created for S3 origins
+	// so callers can reference it from the bucket policy. Unset for
+	// custom origins.
+	OriginAccessControlId pulumi.StringOutput
+	// LogBucketName is the name of the auto-created log bucket. Unset
+	// unless Logging is enabled without a bucket.
+	LogBucketName pulumi.StringOutput
+	// CertificateArn is the ARN of the auto-created ACM certificate.
+	// Unset when a certificate was supplied or no aliases are used.
+	CertificateArn pulumi.StringOutput
+	// ResponseHeadersPolicyId identifies the security headers policy.
+	// Unset unless SecurityHeaders is configured.
+	ResponseHeadersPolicyId pulumi.StringOutput
+	// KeyGroupId identifies the key group created from
+	// SigningPublicKeyPEM. Unset otherwise.
+	KeyGroupId pulumi.StringOutput
+}
+
+// Validate checks required fields and enum values before any resources
+// are created, returning every problem found as one joined error.
+func (c *CloudFrontConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	if err := validateEnums(c); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// NewCloudFrontDistribution creates a CloudFront distribution in front
+// of the configured origin.
+func NewCloudFrontDistribution(ctx *pulumi.Context, name string, config *CloudFrontConfig, opts ...pulumi.ResourceOption) (*CloudFrontDistribution, error) {
+	// Catch enum typos up front so they fail fast instead of mid-deploy
+	// inside AWS.
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	comp := &CloudFrontDistribution{}
+	err := ctx.RegisterComponentResource("denecloud:aws:CloudFrontDistribution", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	resourceTags := tags.MergeTags(config.Environment, config.Tags)
+
+	originConfigs := config.Origins
+	if len(originConfigs) == 0 {
+		originConfigs = []OriginConfig{{
+			OriginId:             name + "-origin",
+			DomainName:           config.OriginDomainName,
+			OriginType:           config.OriginType,
+			OriginProtocolPolicy: config.OriginProtocolPolicy,
+		}}
+	}
+	originIds := map[string]bool{}
+	origins := cloudfront.DistributionOriginArray{}
+	for _, originConfig := range originConfigs {
+		if originIds[originConfig.OriginId] {
+			return nil, fmt.Errorf("duplicate origin id %q", originConfig.OriginId)
+		}
+		originIds[originConfig.OriginId] = true
+		origin, err := comp.buildOrigin(ctx, name, originConfig, parentOpts)
+		if err != nil {
+			return nil, err
+		}
+		origins = append(origins, origin)
+	}
+
+	originGroups := cloudfront.DistributionOriginGroupArray{}
+	for _, group := range config.OriginGroups {
+		if !originIds[group.PrimaryOriginId] || !originIds[group.SecondaryOriginId] {
+			return nil, fmt.Errorf("origin group %q references unknown origin ids %q/%q", group.GroupId, group.PrimaryOriginId, group.SecondaryOriginId)
+		}
+		if originIds[group.GroupId] {
+			return nil, fmt.Errorf("origin group id %q collides with an origin id", group.GroupId)
+		}
+		statusCodes := group.FailoverStatusCodes
+		if len(statusCodes) == 0 {
+			statusCodes = []int{500, 502, 503, 504}
+		}
+		originGroups = append(originGroups, cloudfront.DistributionOriginGroupArgs{
+			OriginId: pulumi.String(group.GroupId),
+			FailoverCriteria: &cloudfront.DistributionOriginGroupFailoverCriteriaArgs{
+				StatusCodes: pulumi.ToIntArray(statusCodes),
+			},
+			Members: cloudfront.DistributionOriginGroupMemberArray{
+				cloudfront.DistributionOriginGroupMemberArgs{OriginId: pulumi.String(group.PrimaryOriginId)},
+				cloudfront.DistributionOriginGroupMemberArgs{OriginId: pulumi.String(group.SecondaryOriginId)},
+			},
+		})
+		// Behaviors may target the group just like an origin.
+		originIds[group.GroupId] = true
+	}
+
+	orderedBehaviors := cloudfront.DistributionOrderedCacheBehaviorArray{}
+	for _, behavior := range config.OrderedCacheBehaviors {
+		if !originIds[behavior.TargetOriginId] {
+			return nil, fmt.Errorf("cache behavior %q targets unknown origin id %q", behavior.PathPattern, behavior.TargetOriginId)
+		}
+		built, err := buildOrderedBehavior(behavior)
+		if err != nil {
+			return nil, err
+		}
+		orderedBehaviors = append(orderedBehaviors, built)
+	}
+
+	defaultFunctionAssociations := cloudfront.DistributionDefaultCacheBehaviorFunctionAssociationArray{}
+	defaultLambdaAssociations := cloudfront.DistributionDefaultCacheBehaviorLambdaFunctionAssociationArray{}
+	for _, association := range config.FunctionAssociations {
+		if err := association.validate(); err != nil {
+			return nil, err
+		}
+		if association.FunctionArn != "" {
+			defaultFunctionAssociations = append(defaultFunctionAssociations, cloudfront.DistributionDefaultCacheBehaviorFunctionAssociationArgs{
+				EventType:   pulumi.String(association.EventType),
+				FunctionArn: pulumi.String(association.FunctionArn),
+			})
+		} else {
+			defaultLambdaAssociations = append(defaultLambdaAssociations, cloudfront.DistributionDefaultCacheBehaviorLambdaFunctionAssociationArgs{
+				EventType:   pulumi.String(association.EventType),
+				LambdaArn:   pulumi.String(association.LambdaFunctionArn),
+				IncludeBody: pulumi.Bool(association.IncludeBody),
+			})
+		}
+	}
+
+	errorResponses := cloudfront.DistributionCustomErrorResponseArray{}
+	for _, errorResponse := range config.CustomErrorResponses {
+		if errorResponse.ErrorCode < 400 || errorResponse.ErrorCode > 599 {
+			return nil, fmt.Errorf("invalid error code %d: must be an HTTP error status", errorResponse.ErrorCode)
+		}
+		responseArgs := cloudfront.DistributionCustomErrorResponseArgs{
+			ErrorCode:          pulumi.Int(errorResponse.ErrorCode),
+			ErrorCachingMinTtl: pulumi.Int(errorResponse.ErrorCachingMinTTLSeconds),
+		}
+		if errorResponse.ResponseCode != 0 {
+			if errorResponse.ResponseCode < 200 || errorResponse.ResponseCode > 599 {
+				return nil, fmt.Errorf("invalid response code %d: must be an HTTP status", errorResponse.ResponseCode)
+			}
+			responseArgs.ResponseCode = pulumi.Int(errorResponse.ResponseCode)
+			responseArgs.ResponsePagePath = pulumi.String(errorResponse.ResponsePagePath)
+		}
+		errorResponses = append(errorResponses, responseArgs)
+	}
+
+	viewerProtocol := config.ViewerProtocolPolicy
+	if viewerProtocol == "" {
+		viewerProtocol = "redirect-to-https"
+	}
+	priceClass := config.PriceClass
+	if priceClass == "" {
+		priceClass = profiles.Lookup(config.Environment).CloudFrontPriceClass
+	}
+	if priceClass == "" {
+		priceClass = "PriceClass_100"
+	}
+	defaultTTL := config.DefaultTTLSeconds
+	if defaultTTL == 0 {
+		defaultTTL = 86400
+	}
+	maxTTL := config.MaxTTLSeconds
+	if maxTTL == 0 {
+		maxTTL = 31536000
+	}
+
+	geoRestriction := &cloudfront.DistributionRestrictionsGeoRestrictionArgs{
+		RestrictionType: pulumi.String("none"),
+	}
+	if geo := config.GeoRestriction; geo != nil {
+		switch geo.RestrictionType {
+		case "whitelist", "blacklist":
+			if len(geo.Locations) == 0 {
+				return nil, fmt.Errorf("geo restriction %q requires at least one location", geo.RestrictionType)
+			}
+		case "none":
+			if len(geo.Locations) != 0 {
+				return nil, fmt.Errorf("geo restriction none must not list locations")
+			}
+		default:
+			return nil, fmt.Errorf("invalid geo restriction type %q: must be whitelist, blacklist or none", geo.RestrictionType)
+		}
+		geoRestriction.RestrictionType = pulumi.String(geo.RestrictionType)
+		geoRestriction.Locations = pulumi.ToStringArray(geo.Locations)
+	}
+
+	defaultBehavior := &cloudfront.DistributionDefaultCacheBehaviorArgs{
+		TargetOriginId:       pulumi.String(originConfigs[0].OriginId),
+		ViewerProtocolPolicy: pulumi.String(viewerProtocol),
+		AllowedMethods: pulumi.StringArray{
+			pulumi.String("GET"), pulumi.String("HEAD"), pulumi.String("OPTIONS"),
+		},
+		CachedMethods: pulumi.StringArray{
+			pulumi.String("GET"), pulumi.String("HEAD"),
+		},
+		FunctionAssociations:       defaultFunctionAssociations,
+		LambdaFunctionAssociations: defaultLambdaAssociations,
+	}
+	if config.OriginRequestPolicyId != "" && config.CachePolicyId == "" {
+		return nil, fmt.Errorf("OriginRequestPolicyId requires a CachePolicyId because it cannot be combined with ForwardedValues")
+	}
+	if config.CachePolicyId != "" {
+		// Cache policies replace the legacy ForwardedValues block and
+		// carry their own TTL settings.
+		defaultBehavior.CachePolicyId = pulumi.String(config.CachePolicyId)
+		if config.OriginRequestPolicyId != "" {
+			defaultBehavior.OriginRequestPolicyId = pulumi.String(config.OriginRequestPolicyId)
+		}
+	} else {
+		defaultBehavior.MinTtl = pulumi.Int(config.MinTTLSeconds)
+		defaultBehavior.DefaultTtl = pulumi.Int(defaultTTL)
+		defaultBehavior.MaxTtl = pulumi.Int(maxTTL)
+		defaultBehavior.ForwardedValues = &cloudfront.DistributionDefaultCacheBehaviorForwardedValuesArgs{
+			QueryString: pulumi.Bool(false),
+			Cookies: &cloudfront.DistributionDefaultCacheBehaviorForwardedValuesCookiesArgs{
+				Forward: pulumi.String("none"),
+			},
+		}
+	}
+	trustedKeyGroups := pulumi.StringArray{}
+	for _, keyGroupId := range config.TrustedKeyGroupIds {
+		trustedKeyGroups = append(trustedKeyGroups, pulumi.String(keyGroupId))
+	}
+	if config.SigningPublicKeyPEM != "" {
+		publicKey, err := cloudfront.NewPublicKey(ctx, name+"-signing", &cloudfront.PublicKeyArgs{
+			Name:       pulumi.String(name + "-signing"),
+			EncodedKey: pulumi.String(config.SigningPublicKeyPEM),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		keyGroup, err := cloudfront.NewKeyGroup(ctx, name+"-signing", &cloudfront.KeyGroupArgs{
+			Name:  pulumi.String(name + "-signing"),
+			Items: pulumi.StringArray{publicKey.ID()},
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		trustedKeyGroups = append(trustedKeyGroups, keyGroup.ID())
+		comp.KeyGroupId = keyGroup.ID().ToStringOutput()
+	}
+	if len(trustedKeyGroups) > 0 {
+		defaultBehavior.TrustedKeyGroups = trustedKeyGroups
+	}
+
+	if config.ResponseHeadersPolicyId != "" && config.SecurityHeaders != nil {
+		return nil, fmt.Errorf("ResponseHeadersPolicyId and SecurityHeaders are mutually exclusive")
+	}
+	if config.ResponseHeadersPolicyId != "" {
+		defaultBehavior.ResponseHeadersPolicyId = pulumi.String(config.ResponseHeadersPolicyId)
+	}
+	if config.SecurityHeaders != nil {
+		policyId, err := comp.buildSecurityHeadersPolicy(ctx, name, config.SecurityHeaders, parentOpts)
+		if err != nil {
+			return nil, err
+		}
+		defaultBehavior.ResponseHeadersPolicyId = policyId
+	}
+
+	viewerCertificate := &cloudfront.DistributionViewerCertificateArgs{}
+	switch {
+	case config.CertificateArn != "":
+		viewerCertificate.AcmCertificateArn = pulumi.String(config.CertificateArn)
+		viewerCertificate.SslSupportMethod = pulumi.String("sni-only")
+		viewerCertificate.MinimumProtocolVersion = pulumi.String("TLSv1.2_2021")
+	case len(config.Aliases) > 0:
+		certificateArn, err := comp.buildCertificate(ctx, name, config, parentOpts)
+		if err != nil {
+			return nil, err
+		}
+		viewerCertificate.AcmCertificateArn = certificateArn
+		viewerCertificate.SslSupportMethod = pulumi.String("sni-only")
+		viewerCertificate.MinimumProtocolVersion = pulumi.String("TLSv1.2_2021")
+	default:
+		// Without aliases the distribution serves its *.cloudfront.net
+		// domain, which uses the default certificate.
+		viewerCertificate.CloudfrontDefaultCertificate = pulumi.Bool(true)
+	}
+
+	httpVersion := config.HttpVersion
+	switch httpVersion {
+	case "":
+		httpVersion = "http2"
+	case "http1.1", "http2", "http2and3", "http3":
+	default:
+		return nil, fmt.Errorf("invalid HttpVersion %q: must be http1.1, http2, http2and3 or http3", config.HttpVersion)
+	}
+
+	distributionArgs := &cloudfront.DistributionArgs{
+		HttpVersion:           pulumi.String(httpVersion),
+		Enabled:               pulumi.Bool(true),
+		IsIpv6Enabled:         pulumi.Bool(true),
+		Comment:               pulumi.Sprintf("%s (%s)", name, config.Environment),
+		PriceClass:            pulumi.String(priceClass),
+		Aliases:               pulumi.ToStringArray(config.Aliases),
+		Origins:               origins,
+		OriginGroups:          originGroups,
+		OrderedCacheBehaviors: orderedBehaviors,
+		CustomErrorResponses:  errorResponses,
+		DefaultCacheBehavior:  defaultBehavior,
+		DefaultRootObject:     pulumi.String(config.DefaultRootObject),
+		Restrictions: &cloudfront.DistributionRestrictionsArgs{
+			GeoRestriction: geoRestriction,
+		},
+		ViewerCertificate: viewerCertificate,
+		Tags:              resourceTags,
+	}
+
+	if config.Logging != nil {
+		loggingArgs, err := comp.buildLogging(ctx, name, config.Logging, resourceTags, parentOpts)
+		if err != nil {
+			return nil, err
+		}
+		distributionArgs.LoggingConfig = loggingArgs
+	}
+
+	if config.WebAclArn != nil {
+		distributionArgs.WebAclId = config.WebAclArn.ToStringOutput().ToStringPtrOutput()
+	}
+
+	distributionOpts := parentOpts
+	if config.ImportID != "" {
+		distributionOpts = append([]pulumi.ResourceOption{pulumi.Import(pulumi.ID(config.ImportID))}, parentOpts...)
+	}
+	distribution, err := cloudfront.NewDistribution(ctx, name, distributionArgs, distributionOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Distribution = distribution
+	comp.DomainName = distribution.DomainName
+
+	outputs := pulumi.Map{
+		"domainName": comp.DomainName,
+	}
+	for key, value := range map[string]pulumi.StringOutput{
+		"originAccessControlId":   comp.OriginAccessControlId,
+		"logBucketName":           comp.LogBucketName,
+		"certificateArn":          comp.CertificateArn,
+		"responseHeadersPolicyId": comp.ResponseHeadersPolicyId,
+		"keyGroupId":              comp.KeyGroupId,
+	} {
+		if value != (pulumi.StringOutput{}) {
+			outputs[key] = value
+		}
+	}
+	if err := ctx.RegisterResourceOutputs(comp, outputs); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
+
+// sortedKeys returns the map's keys in sorted order so resources are
+// created deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateEnums checks the free-form enum fields against the values
+// CloudFront accepts.
+func validateEnums(config *CloudFrontConfig) error {
+	if err := validatePriceClass(config.PriceClass); err != nil {
+		return err
+	}
+	if err := validateViewerProtocolPolicy(config.ViewerProtocolPolicy); err != nil {
+		return err
+	}
+	if err := validateOriginProtocolPolicy(config.OriginProtocolPolicy); err != nil {
+		return err
+	}
+	for _, origin := range config.Origins {
+		if err := validateOriginProtocolPolicy(origin.OriginProtocolPolicy); err != nil {
+			return fmt.Errorf("origin %q: %w", origin.OriginId, err)
+		}
+	}
+	for _, behavior := range config.OrderedCacheBehaviors {
+		if err := validateViewerProtocolPolicy(behavior.ViewerProtocolPolicy); err != nil {
+			return fmt.Errorf("cache behavior %q: %w", behavior.PathPattern, err)
+		}
+	}
+	return nil
+}
+
+func validatePriceClass(priceClass string) error {
+	switch priceClass {
+	case "", "PriceClass_100", "PriceClass_200", "PriceClass_All":
+		return nil
+	}
+	return fmt.Errorf("invalid PriceClass %q: must be PriceClass_100, PriceClass_200 or PriceClass_All", priceClass)
+}
+
+func validateViewerProtocolPolicy(policy string) error {
+	switch policy {
+	case "", "allow-all", "https-only", "redirect-to-https":
+		return nil
+	}
+	return fmt.Errorf("invalid ViewerProtocolPolicy %q: must be allow-all, https-only or redirect-to-https", policy)
+}
+
+func validateOriginProtocolPolicy(policy string) error {
+	switch policy {
+	case "", "http-only", "https-only", "match-viewer":
+		return nil
+	}
+	return fmt.Errorf("invalid OriginProtocolPolicy %q: must be http-only, https-only or match-viewer", policy)
+}
+
+// buildSecurityHeadersPolicy creates a response headers policy with the
+// configured security headers.
+func (comp *CloudFrontDistribution) buildSecurityHeadersPolicy(ctx *pulumi.Context, name string, headers *SecurityHeadersConfig, parentOpts []pulumi.ResourceOption) (pulumi.StringInput, error) {
+	hstsMaxAge := headers.HSTSMaxAgeSeconds
+	if hstsMaxAge == 0 {
+		hstsMaxAge = 31536000
+	}
+	frameOption := headers.FrameOption
+	if frameOption == "" {
+		frameOption = "DENY"
+	}
+	referrerPolicy := headers.ReferrerPolicy
+	if referrerPolicy == "" {
+		referrerPolicy = "strict-origin-when-cross-origin"
+	}
+
+	securityHeaders := &cloudfront.ResponseHeadersPolicySecurityHeadersConfigArgs{
+		StrictTransportSecurity: &cloudfront.ResponseHeadersPolicySecurityHeadersConfigStrictTransportSecurityArgs{
+			AccessControlMaxAgeSec: pulumi.Int(hstsMaxAge),
+			IncludeSubdomains:      pulumi.Bool(true),
+			Override:               pulumi.Bool(true),
+		},
+		ContentTypeOptions: &cloudfront.ResponseHeadersPolicySecurityHeadersConfigContentTypeOptionsArgs{
+			Override: pulumi.Bool(true),
+		},
+		FrameOptions: &cloudfront.ResponseHeadersPolicySecurityHeadersConfigFrameOptionsArgs{
+			FrameOption: pulumi.String(frameOption),
+			Override:    pulumi.Bool(true),
+		},
+		ReferrerPolicy: &cloudfront.ResponseHeadersPolicySecurityHeadersConfigReferrerPolicyArgs{
+			ReferrerPolicy: pulumi.String(referrerPolicy),
+			Override:       pulumi.Bool(true),
+		},
+	}
+	if headers.ContentSecurityPolicy != "" {
+		securityHeaders.ContentSecurityPolicy = &cloudfront.ResponseHeadersPolicySecurityHeadersConfigContentSecurityPolicyArgs{
+			ContentSecurityPolicy: pulumi.String(headers.ContentSecurityPolicy),
+			Override:              pulumi.Bool(true),
+		}
+	}
+
+	policy, err := cloudfront.NewResponseHeadersPolicy(ctx, name+"-security-headers", &cloudfront.ResponseHeadersPolicyArgs{
+		Name:                  pulumi.String(name + "-security-headers"),
+		SecurityHeadersConfig: securityHeaders,
+	}, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.ResponseHeadersPolicyId = policy.ID().ToStringOutput()
+	return policy.ID(), nil
+}
+
+// buildCertificate creates an ACM certificate for the aliases in
+// us-east-1 (the only region CloudFront accepts certificates from) and,
+// when a hosted zone is known, its DNS validation records.
+func (comp *CloudFrontDistribution) buildCertificate(ctx *pulumi.Context, name string, config *CloudFrontConfig, parentOpts []pulumi.ResourceOption) (pulumi.StringInput, error) {
+	usEast1, err := aws.NewProvider(ctx, name+"-us-east-1", &aws.ProviderArgs{
+		Region: pulumi.String("us-east-1"),
+	}, pulumi.Parent(comp))
+	if err != nil {
+		return nil, err
+	}
+	certOpts := append(parentOpts, pulumi.Provider(usEast1))
+
+	certificate, err := acm.NewCertificate(ctx, name, &acm.CertificateArgs{
+		DomainName:              pulumi.String(config.Aliases[0]),
+		SubjectAlternativeNames: pulumi.ToStringArray(config.Aliases[1:]),
+		ValidationMethod:        pulumi.String("DNS"),
+	}, certOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.CertificateArn = certificate.Arn
+
+	if config.Route53ZoneId == "" {
+		return certificate.Arn, nil
+	}
+
+	fqdns := pulumi.StringArray{}
+	for i := range config.Aliases {
+		option := certificate.DomainValidationOptions.Index(pulumi.Int(i))
+		record, err := route53.NewRecord(ctx, fmt.Sprintf("%s-validation-%d", name, i), &route53.RecordArgs{
+			ZoneId:         pulumi.String(config.Route53ZoneId),
+			Name:           option.ResourceRecordName().Elem(),
+			Type:           option.ResourceRecordType().Elem(),
+			Records:        pulumi.StringArray{option.ResourceRecordValue().Elem()},
+			Ttl:            pulumi.Int(60),
+			AllowOverwrite: pulumi.Bool(true),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		fqdns = append(fqdns, record.Fqdn)
+	}
+	validation, err := acm.NewCertificateValidation(ctx, name, &acm.CertificateValidationArgs{
+		CertificateArn:        certificate.Arn,
+		ValidationRecordFqdns: fqdns,
+	}, certOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return validation.CertificateArn, nil
+}
+
+// buildLogging resolves the log delivery target, creating a bucket set
+// up for CloudFront log delivery when none is supplied.
+func (comp *CloudFrontDistribution) buildLogging(ctx *pulumi.Context, name string, logging *LoggingConfig, resourceTags pulumi.StringMap, parentOpts []pulumi.ResourceOption) (*cloudfront.DistributionLoggingConfigArgs, error) {
+	loggingArgs := &cloudfront.DistributionLoggingConfigArgs{
+		IncludeCookies: pulumi.Bool(logging.IncludeCookies),
+		Prefix:         pulumi.String(logging.Prefix),
+	}
+	if logging.BucketDomainName != "" {
+		loggingArgs.Bucket = pulumi.String(logging.BucketDomainName)
+		return loggingArgs, nil
+	}
+
+	bucket, err := s3.NewBucketV2(ctx, name+"-logs", &s3.BucketV2Args{
+		Tags: resourceTags,
+	}, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	// CloudFront log delivery writes with ACLs, which modern buckets
+	// disable by default.
+	ownership, err := s3.NewBucketOwnershipControls(ctx, name+"-logs", &s3.BucketOwnershipControlsArgs{
+		Bucket: bucket.ID(),
+		Rule: &s3.BucketOwnershipControlsRuleArgs{
+			ObjectOwnership: pulumi.String("BucketOwnerPreferred"),
+		},
+	}, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	_, err = s3.NewBucketAclV2(ctx, name+"-logs", &s3.BucketAclV2Args{
+		Bucket: bucket.ID(),
+		Acl:    pulumi.String("log-delivery-write"),
+	}, append(parentOpts, pulumi.DependsOn([]pulumi.Resource{ownership}))...)
+	if err != nil {
+		return nil, err
+	}
+	comp.LogBucketName = bucket.Bucket
+	loggingArgs.Bucket = bucket.BucketDomainName
+	return loggingArgs, nil
+}
+
+// buildOrigin converts one origin config into distribution origin args,
+// creating an origin access control for S3 origins.
+func (comp *CloudFrontDistribution) buildOrigin(ctx *pulumi.Context, name string, config OriginConfig, parentOpts []pulumi.ResourceOption) (cloudfront.DistributionOriginArgs, error) {
+	origin := cloudfront.DistributionOriginArgs{
+		DomainName: pulumi.String(config.DomainName),
+		OriginId:   pulumi.String(config.OriginId),
+	}
+	if config.OriginDomain != nil {
+		origin.DomainName = config.OriginDomain
+	}
+	if config.OriginPath != "" {
+		origin.OriginPath = pulumi.String(config.OriginPath)
+	}
+	if len(config.CustomHeaders) > 0 {
+		headers := cloudfront.DistributionOriginCustomHeaderArray{}
+		for _, headerName := range sortedKeys(config.CustomHeaders) {
+			headers = append(headers, cloudfront.DistributionOriginCustomHeaderArgs{
+				Name:  pulumi.String(headerName),
+				Value: pulumi.String(config.CustomHeaders[headerName]),
+			})
+		}
+		origin.CustomHeaders = headers
+	}
+	if config.ConnectionAttempts != 0 {
+		if config.ConnectionAttempts < 1 || config.ConnectionAttempts > 3 {
+			return origin, fmt.Errorf("origin %q: ConnectionAttempts must be between 1 and 3, got %d", config.OriginId, config.ConnectionAttempts)
+		}
+		origin.ConnectionAttempts = pulumi.Int(config.ConnectionAttempts)
+	}
+	if config.ConnectionTimeoutSeconds != 0 {
+		if config.ConnectionTimeoutSeconds < 1 || config.ConnectionTimeoutSeconds > 10 {
+			return origin, fmt.Errorf("origin %q: ConnectionTimeoutSeconds must be between 1 and 10, got %d", config.OriginId, config.ConnectionTimeoutSeconds)
+		}
+		origin.ConnectionTimeout = pulumi.Int(config.ConnectionTimeoutSeconds)
+	}
+	if shield := config.OriginShield; shield != nil {
+		if shield.Enabled && shield.Region == "" {
+			return origin, fmt.Errorf("origin %q: OriginShield requires a Region", config.OriginId)
+		}
+		origin.OriginShield = &cloudfront.DistributionOriginOriginShieldArgs{
+			Enabled:            pulumi.Bool(shield.Enabled),
+			OriginShieldRegion: pulumi.String(shield.Region),
+		}
+	}
+	switch config.OriginType {
+	case "", "custom":
+		originProtocol := config.OriginProtocolPolicy
+		if originProtocol == "" {
+			originProtocol = "https-only"
+		}
+		customOrigin := &cloudfront.DistributionOriginCustomOriginConfigArgs{
+			HttpPort:             pulumi.Int(80),
+			HttpsPort:            pulumi.Int(443),
+			OriginProtocolPolicy: pulumi.String(originProtocol),
+			OriginSslProtocols:   pulumi.StringArray{pulumi.String("TLSv1.2")},
+		}
+		if config.OriginReadTimeoutSeconds != 0 {
+			if config.OriginReadTimeoutSeconds < 1 || config.OriginReadTimeoutSeconds > 180 {
+				return origin, fmt.Errorf("origin %q: OriginReadTimeoutSeconds must be between 1 and 180, got %d", config.OriginId, config.OriginReadTimeoutSeconds)
+			}
+			customOrigin.OriginReadTimeout = pulumi.Int(config.OriginReadTimeoutSeconds)
+		}
+		if config.OriginKeepaliveTimeoutSeconds != 0 {
+			if config.OriginKeepaliveTimeoutSeconds < 1 || config.OriginKeepaliveTimeoutSeconds > 60 {
+				return origin, fmt.Errorf("origin %q: OriginKeepaliveTimeoutSeconds must be between 1 and 60, got %d", config.OriginId, config.OriginKeepaliveTimeoutSeconds)
+			}
+			customOrigin.OriginKeepaliveTimeout = pulumi.Int(config.OriginKeepaliveTimeoutSeconds)
+		}
+		origin.CustomOriginConfig = customOrigin
+	case "s3":
+		if config.OriginReadTimeoutSeconds != 0 || config.OriginKeepaliveTimeoutSeconds != 0 {
+			return origin, fmt.Errorf("origin %q: read and keepalive timeouts only apply to custom origins", config.OriginId)
+		}
+		// S3 origins are reached with SigV4-signed requests through an
+		// origin access control; no custom-origin block is allowed.
+		oac, err := cloudfront.NewOriginAccessControl(ctx, name+"-"+config.OriginId, &cloudfront.OriginAccessControlArgs{
+			Name:                          pulumi.String(name + "-" + config.OriginId),
+			OriginAccessControlOriginType: pulumi.String("s3"),
+			SigningBehavior:               pulumi.String("always"),
+			SigningProtocol:               pulumi.String("sigv4"),
+		}, parentOpts...)
+		if err != nil {
+			return origin, err
+		}
+		origin.OriginAccessControlId = oac.ID()
+		comp.OriginAccessControlId = oac.ID().ToStringOutput()
+	default:
+		return origin, fmt.Errorf("invalid OriginType %q: must be custom or s3", config.OriginType)
+	}
+	return origin, nil
+}
+
+// buildOrderedBehavior converts one cache behavior config into ordered
+// cache behavior args.
+func buildOrderedBehavior(config CacheBehaviorConfig) (cloudfront.DistributionOrderedCacheBehaviorArgs, error) {
+	functionAssociations := cloudfront.DistributionOrderedCacheBehaviorFunctionAssociationArray{}
+	lambdaAssociations := cloudfront.DistributionOrderedCacheBehaviorLambdaFunctionAssociationArray{}
+	for _, association := range config.FunctionAssociations {
+		if err := association.validate(); err != nil {
+			return cloudfront.DistributionOrderedCacheBehaviorArgs{}, err
+		}
+		if association.FunctionArn != "" {
+			functionAssociations = append(functionAssociations, cloudfront.DistributionOrderedCacheBehaviorFunctionAssociationArgs{
+				EventType:   pulumi.String(association.EventType),
+				FunctionArn: pulumi.String(association.FunctionArn),
+			})
+		} else {
+			lambdaAssociations = append(lambdaAssociations, cloudfront.DistributionOrderedCacheBehaviorLambdaFunctionAssociationArgs{
+				EventType:   pulumi.String(association.EventType),
+				LambdaArn:   pulumi.String(association.LambdaFunctionArn),
+				IncludeBody: pulumi.Bool(association.IncludeBody),
+			})
+		}
+	}
+
+	viewerProtocol := config.ViewerProtocolPolicy
+	if viewerProtocol == "" {
+		viewerProtocol = "redirect-to-https"
+	}
+	allowedMethods := config.AllowedMethods
+	if len(allowedMethods) == 0 {
+		allowedMethods = []string{"GET", "HEAD", "OPTIONS"}
+	}
+	defaultTTL := config.DefaultTTLSeconds
+	if defaultTTL == 0 {
+		defaultTTL = 86400
+	}
+	maxTTL := config.MaxTTLSeconds
+	if maxTTL == 0 {
+		maxTTL = 31536000
+	}
+	behavior := cloudfront.DistributionOrderedCacheBehaviorArgs{
+		PathPattern:          pulumi.String(config.PathPattern),
+		TargetOriginId:       pulumi.String(config.TargetOriginId),
+		ViewerProtocolPolicy: pulumi.String(viewerProtocol),
+		AllowedMethods:       pulumi.ToStringArray(allowedMethods),
+		CachedMethods: pulumi.StringArray{
+			pulumi.String("GET"), pulumi.String("HEAD"),
+		},
+		FunctionAssociations:       functionAssociations,
+		LambdaFunctionAssociations: lambdaAssociations,
+	}
+	if config.OriginRequestPolicyId != "" && config.CachePolicyId == "" {
+		return behavior, fmt.Errorf("cache behavior %q: OriginRequestPolicyId requires a CachePolicyId because it cannot be combined with ForwardedValues", config.PathPattern)
+	}
+	if config.CachePolicyId != "" {
+		// Cache policies replace the legacy ForwardedValues block and
+		// carry their own TTL settings.
+		behavior.CachePolicyId = pulumi.String(config.CachePolicyId)
+		if config.OriginRequestPolicyId != "" {
+			behavior.OriginRequestPolicyId = pulumi.String(config.OriginRequestPolicyId)
+		}
+	} else {
+		behavior.MinTtl = pulumi.Int(config.MinTTLSeconds)
+		behavior.DefaultTtl = pulumi.Int(defaultTTL)
+		behavior.MaxTtl = pulumi.Int(maxTTL)
+		behavior.ForwardedValues = &cloudfront.DistributionOrderedCacheBehaviorForwardedValuesArgs{
+			QueryString: pulumi.Bool(false),
+			Cookies: &cloudfront.DistributionOrderedCacheBehaviorForwardedValuesCookiesArgs{
+				Forward: pulumi.String("none"),
+			},
+		}
+	}
+	if config.ResponseHeadersPolicyId != "" {
+		behavior.ResponseHeadersPolicyId = pulumi.String(config.ResponseHeadersPolicyId)
+	}
+	if len(config.TrustedKeyGroupIds) > 0 {
+		behavior.TrustedKeyGroups = pulumi.ToStringArray(config.TrustedKeyGroupIds)
+	}
+	return behavior, nil
+}
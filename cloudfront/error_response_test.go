@@ -0,0 +1,49 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestSPAErrorRewrite(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.CustomErrorResponses = []ErrorResponseConfig{
+			{ErrorCode: 404, ResponseCode: 200, ResponsePagePath: "/index.html", ErrorCachingMinTTLSeconds: 10},
+		}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	responses := distribution.Inputs["customErrorResponses"].ArrayValue()
+	if len(responses) != 1 {
+		t.Fatalf("expected 1 custom error response, got %d", len(responses))
+	}
+	response := responses[0].ObjectValue()
+	if got := response["errorCode"].NumberValue(); got != 404 {
+		t.Errorf("errorCode = %v, want 404", got)
+	}
+	if got := response["responseCode"].NumberValue(); got != 200 {
+		t.Errorf("responseCode = %v, want 200", got)
+	}
+	if got := response["responsePagePath"].StringValue(); got != "/index.html" {
+		t.Errorf("responsePagePath = %s, want /index.html", got)
+	}
+	if got := response["errorCachingMinTtl"].NumberValue(); got != 10 {
+		t.Errorf("errorCachingMinTtl = %v, want 10", got)
+	}
+}
+
+func TestErrorResponseRejectsBadStatus(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.CustomErrorResponses = []ErrorResponseConfig{{ErrorCode: 42}}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected invalid error code to be rejected")
+	}
+}
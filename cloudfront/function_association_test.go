@@ -0,0 +1,73 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestCloudFrontFunctionAssociation(t *testing.T) {
+	functionArn := "arn:aws:cloudfront::123456789012:function/rewrite"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.FunctionAssociations = []FunctionAssociationConfig{
+			{EventType: "viewer-request", FunctionArn: functionArn},
+		}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	behavior := distribution.Inputs["defaultCacheBehavior"].ObjectValue()
+	associations := behavior["functionAssociations"].ArrayValue()
+	if len(associations) != 1 {
+		t.Fatalf("expected 1 function association, got %d", len(associations))
+	}
+	association := associations[0].ObjectValue()
+	if got := association["eventType"].StringValue(); got != "viewer-request" {
+		t.Errorf("eventType = %s, want viewer-request", got)
+	}
+	if got := association["functionArn"].StringValue(); got != functionArn {
+		t.Errorf("functionArn = %s, want %s", got, functionArn)
+	}
+}
+
+func TestLambdaEdgeAssociation(t *testing.T) {
+	edgeArn := "arn:aws:lambda:us-east-1:123456789012:function:auth:3"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.FunctionAssociations = []FunctionAssociationConfig{
+			{EventType: "origin-request", LambdaFunctionArn: edgeArn, IncludeBody: true},
+		}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	behavior := distribution.Inputs["defaultCacheBehavior"].ObjectValue()
+	associations := behavior["lambdaFunctionAssociations"].ArrayValue()
+	if len(associations) != 1 {
+		t.Fatalf("expected 1 Lambda@Edge association, got %d", len(associations))
+	}
+	association := associations[0].ObjectValue()
+	if got := association["lambdaArn"].StringValue(); got != edgeArn {
+		t.Errorf("lambdaArn = %s, want %s", got, edgeArn)
+	}
+	if !association["includeBody"].BoolValue() {
+		t.Error("includeBody not propagated")
+	}
+}
+
+func TestCloudFrontFunctionRejectsOriginEvents(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.FunctionAssociations = []FunctionAssociationConfig{
+			{EventType: "origin-request", FunctionArn: "arn:aws:cloudfront::123456789012:function/rewrite"},
+		}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected origin-request CloudFront Function to be rejected")
+	}
+}
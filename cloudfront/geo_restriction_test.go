@@ -0,0 +1,44 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestGeoWhitelist(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.GeoRestriction = &GeoRestrictionConfig{
+			RestrictionType: "whitelist",
+			Locations:       []string{"DE", "NL"},
+		}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	geo := distribution.Inputs["restrictions"].ObjectValue()["geoRestriction"].ObjectValue()
+	if got := geo["restrictionType"].StringValue(); got != "whitelist" {
+		t.Errorf("restrictionType = %s, want whitelist", got)
+	}
+	locations := geo["locations"].ArrayValue()
+	if len(locations) != 2 {
+		t.Fatalf("expected 2 locations, got %d", len(locations))
+	}
+	if locations[0].StringValue() != "DE" || locations[1].StringValue() != "NL" {
+		t.Errorf("locations = %v, want [DE NL]", locations)
+	}
+}
+
+func TestGeoWhitelistRequiresLocations(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.GeoRestriction = &GeoRestrictionConfig{RestrictionType: "whitelist"}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected whitelist without locations to be rejected")
+	}
+}
@@ -0,0 +1,49 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestHttpVersionAndRootObjectPropagate(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.HttpVersion = "http2and3"
+		config.DefaultRootObject = "index.html"
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	if got := distribution.Inputs["httpVersion"].StringValue(); got != "http2and3" {
+		t.Errorf("httpVersion = %s, want http2and3", got)
+	}
+	if got := distribution.Inputs["defaultRootObject"].StringValue(); got != "index.html" {
+		t.Errorf("defaultRootObject = %s, want index.html", got)
+	}
+}
+
+func TestHttpVersionDefaultsToHTTP2(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", testConfig())
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	if got := distribution.Inputs["httpVersion"].StringValue(); got != "http2" {
+		t.Errorf("httpVersion = %s, want http2", got)
+	}
+}
+
+func TestHttpVersionRejectsUnknownValue(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.HttpVersion = "spdy"
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected unknown HTTP version to be rejected")
+	}
+}
@@ -0,0 +1,45 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestTrustedKeyGroupIdsAttached(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.TrustedKeyGroupIds = []string{"kg-12345"}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	behavior := distribution.Inputs["defaultCacheBehavior"].ObjectValue()
+	groups := behavior["trustedKeyGroups"].ArrayValue()
+	if len(groups) != 1 || groups[0].StringValue() != "kg-12345" {
+		t.Errorf("trustedKeyGroups = %v, want [kg-12345]", groups)
+	}
+}
+
+func TestSigningKeyCreatesKeyGroup(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.SigningPublicKeyPEM = "-----BEGIN PUBLIC KEY-----\nMFw...\n-----END PUBLIC KEY-----"
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	if keys := mocks.ByType("aws:cloudfront/publicKey:PublicKey"); len(keys) != 1 {
+		t.Fatalf("expected 1 public key, got %d", len(keys))
+	}
+	if groups := mocks.ByType("aws:cloudfront/keyGroup:KeyGroup"); len(groups) != 1 {
+		t.Fatalf("expected 1 key group, got %d", len(groups))
+	}
+
+	distribution := mocks.ByType(distributionToken)[0]
+	behavior := distribution.Inputs["defaultCacheBehavior"].ObjectValue()
+	if len(behavior["trustedKeyGroups"].ArrayValue()) != 1 {
+		t.Error("created key group not trusted on the default behavior")
+	}
+}
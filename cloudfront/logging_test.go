@@ -0,0 +1,61 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const bucketToken = "aws:s3/bucketV2:BucketV2"
+
+func TestLoggingWithSuppliedBucket(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Logging = &LoggingConfig{
+			BucketDomainName: "logs.s3.amazonaws.com",
+			Prefix:           "cdn/",
+			IncludeCookies:   true,
+		}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	logging := distribution.Inputs["loggingConfig"].ObjectValue()
+	if got := logging["bucket"].StringValue(); got != "logs.s3.amazonaws.com" {
+		t.Errorf("logging bucket = %s, want logs.s3.amazonaws.com", got)
+	}
+	if got := logging["prefix"].StringValue(); got != "cdn/" {
+		t.Errorf("logging prefix = %s, want cdn/", got)
+	}
+	if !logging["includeCookies"].BoolValue() {
+		t.Error("includeCookies not propagated")
+	}
+	if buckets := mocks.ByType(bucketToken); len(buckets) != 0 {
+		t.Errorf("expected no bucket for supplied domain, got %d", len(buckets))
+	}
+}
+
+func TestLoggingAutoCreatesBucket(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Logging = &LoggingConfig{Prefix: "cdn/"}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	if buckets := mocks.ByType(bucketToken); len(buckets) != 1 {
+		t.Fatalf("expected 1 auto-created log bucket, got %d", len(buckets))
+	}
+	acls := mocks.ByType("aws:s3/bucketAclV2:BucketAclV2")
+	if len(acls) != 1 {
+		t.Fatalf("expected 1 bucket ACL, got %d", len(acls))
+	}
+	if got := acls[0].Inputs["acl"].StringValue(); got != "log-delivery-write" {
+		t.Errorf("bucket ACL = %s, want log-delivery-write", got)
+	}
+	ownerships := mocks.ByType("aws:s3/bucketOwnershipControls:BucketOwnershipControls")
+	if len(ownerships) != 1 {
+		t.Fatalf("expected 1 ownership controls resource, got %d", len(ownerships))
+	}
+}
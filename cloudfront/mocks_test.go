@@ -0,0 +1,57 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/internal/testmocks"
+)
+
+// testMocks wraps the shared recorder and synthesizes the outputs the
+// cloudfront component reads back during construction.
+type testMocks struct {
+	testmocks.Recorder
+}
+
+func (m *testMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, outputs, err := m.Recorder.NewResource(args)
+	if args.TypeToken == "aws:cloudfront/distribution:Distribution" {
+		outputs["domainName"] = resource.NewStringProperty(args.Name + ".cloudfront.net")
+	}
+	if args.TypeToken == "aws:acm/certificate:Certificate" {
+		domains := []resource.PropertyValue{args.Inputs["domainName"]}
+		if sans, ok := args.Inputs["subjectAlternativeNames"]; ok && sans.IsArray() {
+			domains = append(domains, sans.ArrayValue()...)
+		}
+		var options []resource.PropertyValue
+		for _, domain := range domains {
+			options = append(options, resource.NewObjectProperty(resource.PropertyMap{
+				"domainName":          domain,
+				"resourceRecordName":  resource.NewStringProperty("_validation." + domain.StringValue()),
+				"resourceRecordType":  resource.NewStringProperty("CNAME"),
+				"resourceRecordValue": resource.NewStringProperty("validation.acm-validations.aws."),
+			}))
+		}
+		outputs["domainValidationOptions"] = resource.NewArrayProperty(options)
+	}
+	return id, outputs, err
+}
+
+// runTest executes a Pulumi program against the mock monitor and fails
+// the test if the program errors.
+func runTest(t *testing.T, program pulumi.RunFunc) *testMocks {
+	t.Helper()
+	mocks := &testMocks{}
+	testmocks.Run(t, mocks, program)
+	return mocks
+}
+
+// testConfig returns a minimal valid config for tests to extend.
+func testConfig() *CloudFrontConfig {
+	return &CloudFrontConfig{
+		Environment:      "dev",
+		OriginDomainName: "app.internal.example.com",
+	}
+}
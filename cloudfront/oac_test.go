@@ -0,0 +1,56 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	distributionToken = "aws:cloudfront/distribution:Distribution"
+	oacToken          = "aws:cloudfront/originAccessControl:OriginAccessControl"
+)
+
+func TestS3OriginUsesOriginAccessControl(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.OriginType = "s3"
+		config.OriginDomainName = "assets.s3.us-east-1.amazonaws.com"
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	oacs := mocks.ByType(oacToken)
+	if len(oacs) != 1 {
+		t.Fatalf("expected 1 origin access control, got %d", len(oacs))
+	}
+	oac := oacs[0]
+	if got := oac.Inputs["signingProtocol"].StringValue(); got != "sigv4" {
+		t.Errorf("signingProtocol = %s, want sigv4", got)
+	}
+
+	distribution := mocks.ByType(distributionToken)[0]
+	origin := distribution.Inputs["origins"].ArrayValue()[0].ObjectValue()
+	if !origin["customOriginConfig"].IsNull() {
+		t.Error("S3 origin must not carry a custom origin config")
+	}
+	if origin["originAccessControlId"].IsNull() {
+		t.Error("S3 origin does not reference the origin access control")
+	}
+}
+
+func TestCustomOriginOmitsOriginAccessControl(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", testConfig())
+		return err
+	})
+
+	if oacs := mocks.ByType(oacToken); len(oacs) != 0 {
+		t.Errorf("expected no origin access control for custom origins, got %d", len(oacs))
+	}
+	distribution := mocks.ByType(distributionToken)[0]
+	origin := distribution.Inputs["origins"].ArrayValue()[0].ObjectValue()
+	if origin["customOriginConfig"].IsNull() {
+		t.Error("custom origin is missing its custom origin config")
+	}
+}
@@ -0,0 +1,66 @@
+package cloudfront
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Option mutates a CloudFrontConfig. Options are applied in order, so
+// later options override earlier ones.
+type Option func(*CloudFrontConfig)
+
+// WithEnvironment sets the environment tag, e.g. "dev" or "prod".
+func WithEnvironment(environment string) Option {
+	return func(c *CloudFrontConfig) { c.Environment = environment }
+}
+
+// WithOriginDomain sets the single-origin domain name and type.
+func WithOriginDomain(domainName, originType string) Option {
+	return func(c *CloudFrontConfig) {
+		c.OriginDomainName = domainName
+		c.OriginType = originType
+	}
+}
+
+// WithOrigin appends one origin to the distribution.
+func WithOrigin(origin OriginConfig) Option {
+	return func(c *CloudFrontConfig) { c.Origins = append(c.Origins, origin) }
+}
+
+// WithPriceClass sets the distribution price class.
+func WithPriceClass(priceClass string) Option {
+	return func(c *CloudFrontConfig) { c.PriceClass = priceClass }
+}
+
+// WithAliases sets the alternate domain names and their certificate.
+func WithAliases(certificateArn string, aliases ...string) Option {
+	return func(c *CloudFrontConfig) {
+		c.Aliases = aliases
+		c.CertificateArn = certificateArn
+	}
+}
+
+// WithLogging enables access logging.
+func WithLogging(logging *LoggingConfig) Option {
+	return func(c *CloudFrontConfig) { c.Logging = logging }
+}
+
+// WithTags merges tags into the resource tag set.
+func WithTags(tags map[string]string) Option {
+	return func(c *CloudFrontConfig) {
+		if c.Tags == nil {
+			c.Tags = map[string]string{}
+		}
+		for k, v := range tags {
+			c.Tags[k] = v
+		}
+	}
+}
+
+// NewCloudFrontDistributionWithOptions builds a CloudFrontConfig from
+// functional options and creates the distribution. Equivalent to
+// NewCloudFrontDistribution with a hand-built config.
+func NewCloudFrontDistributionWithOptions(ctx *pulumi.Context, name string, options ...Option) (*CloudFrontDistribution, error) {
+	config := &CloudFrontConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return NewCloudFrontDistribution(ctx, name, config)
+}
@@ -0,0 +1,75 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestFailoverOriginGroup(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{OriginId: "primary", DomainName: "primary.example.com"},
+				{OriginId: "fallback", DomainName: "fallback.example.com"},
+			},
+			OriginGroups: []OriginGroupConfig{
+				{
+					GroupId:             "failover",
+					PrimaryOriginId:     "primary",
+					SecondaryOriginId:   "fallback",
+					FailoverStatusCodes: []int{502, 503, 504},
+				},
+			},
+			OrderedCacheBehaviors: []CacheBehaviorConfig{
+				{PathPattern: "/api/*", TargetOriginId: "failover"},
+			},
+		})
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	groups := distribution.Inputs["originGroups"].ArrayValue()
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 origin group, got %d", len(groups))
+	}
+	group := groups[0].ObjectValue()
+	if got := group["originId"].StringValue(); got != "failover" {
+		t.Errorf("group id = %s, want failover", got)
+	}
+	codes := group["failoverCriteria"].ObjectValue()["statusCodes"].ArrayValue()
+	if len(codes) != 3 || codes[0].NumberValue() != 502 {
+		t.Errorf("failover status codes = %v, want [502 503 504]", codes)
+	}
+	members := group["members"].ArrayValue()
+	if len(members) != 2 {
+		t.Fatalf("expected 2 members, got %d", len(members))
+	}
+	if got := members[0].ObjectValue()["originId"].StringValue(); got != "primary" {
+		t.Errorf("first member = %s, want primary", got)
+	}
+
+	behavior := distribution.Inputs["orderedCacheBehaviors"].ArrayValue()[0].ObjectValue()
+	if got := behavior["targetOriginId"].StringValue(); got != "failover" {
+		t.Errorf("behavior target = %s, want the origin group", got)
+	}
+}
+
+func TestOriginGroupRejectsUnknownMember(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{OriginId: "primary", DomainName: "primary.example.com"},
+			},
+			OriginGroups: []OriginGroupConfig{
+				{GroupId: "failover", PrimaryOriginId: "primary", SecondaryOriginId: "missing"},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected origin group with unknown member to be rejected")
+	}
+}
@@ -0,0 +1,62 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestCustomHeadersAndOriginShield(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{
+					OriginId:      "app",
+					DomainName:    "app.example.com",
+					CustomHeaders: map[string]string{"X-Origin-Secret": "s3cret"},
+					OriginShield:  &OriginShieldConfig{Enabled: true, Region: "eu-central-1"},
+				},
+			},
+		})
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	origin := distribution.Inputs["origins"].ArrayValue()[0].ObjectValue()
+
+	headers := origin["customHeaders"].ArrayValue()
+	if len(headers) != 1 {
+		t.Fatalf("expected 1 custom header, got %d", len(headers))
+	}
+	header := headers[0].ObjectValue()
+	if got := header["name"].StringValue(); got != "X-Origin-Secret" {
+		t.Errorf("header name = %s, want X-Origin-Secret", got)
+	}
+	if got := header["value"].StringValue(); got != "s3cret" {
+		t.Errorf("header value = %s, want s3cret", got)
+	}
+
+	shield := origin["originShield"].ObjectValue()
+	if !shield["enabled"].BoolValue() {
+		t.Error("origin shield not enabled")
+	}
+	if got := shield["originShieldRegion"].StringValue(); got != "eu-central-1" {
+		t.Errorf("origin shield region = %s, want eu-central-1", got)
+	}
+}
+
+func TestOriginShieldRequiresRegion(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{OriginId: "app", DomainName: "app.example.com", OriginShield: &OriginShieldConfig{Enabled: true}},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected enabled origin shield without a region to be rejected")
+	}
+}
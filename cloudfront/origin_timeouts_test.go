@@ -0,0 +1,77 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestOriginConnectionAndTimeoutTuning(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{
+					OriginId:                      "app",
+					DomainName:                    "app.example.com",
+					ConnectionAttempts:            2,
+					ConnectionTimeoutSeconds:      5,
+					OriginReadTimeoutSeconds:      60,
+					OriginKeepaliveTimeoutSeconds: 30,
+				},
+			},
+		})
+		return err
+	})
+
+	distribution := mocks.ByType(distributionToken)[0]
+	origin := distribution.Inputs["origins"].ArrayValue()[0].ObjectValue()
+	if got := origin["connectionAttempts"].NumberValue(); got != 2 {
+		t.Errorf("connectionAttempts = %v, want 2", got)
+	}
+	if got := origin["connectionTimeout"].NumberValue(); got != 5 {
+		t.Errorf("connectionTimeout = %v, want 5", got)
+	}
+	customOrigin := origin["customOriginConfig"].ObjectValue()
+	if got := customOrigin["originReadTimeout"].NumberValue(); got != 60 {
+		t.Errorf("originReadTimeout = %v, want 60", got)
+	}
+	if got := customOrigin["originKeepaliveTimeout"].NumberValue(); got != 30 {
+		t.Errorf("originKeepaliveTimeout = %v, want 30", got)
+	}
+}
+
+func TestOriginReadTimeoutRangeEnforced(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{OriginId: "app", DomainName: "app.example.com", OriginReadTimeoutSeconds: 300},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a read timeout above 180 to be rejected")
+	}
+}
+
+func TestOriginTimeoutsRejectedForS3Origins(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewCloudFrontDistribution(ctx, "cdn", &CloudFrontConfig{
+			Environment: "dev",
+			Origins: []OriginConfig{
+				{
+					OriginId:                 "assets",
+					DomainName:               "assets.s3.us-east-1.amazonaws.com",
+					OriginType:               "s3",
+					OriginReadTimeoutSeconds: 60,
+				},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected origin timeouts on an S3 origin to be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const responseHeadersPolicyToken = "aws:cloudfront/responseHeadersPolicy:ResponseHeadersPolicy"
+
+func TestSecurityHeadersPolicy(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.SecurityHeaders = &SecurityHeadersConfig{
+			HSTSMaxAgeSeconds:     63072000,
+			FrameOption:           "SAMEORIGIN",
+			ContentSecurityPolicy: "default-src 'self'",
+		}
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	})
+
+	policies := mocks.ByType(responseHeadersPolicyToken)
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 response headers policy, got %d", len(policies))
+	}
+	headers := policies[0].Inputs["securityHeadersConfig"].ObjectValue()
+	hsts := headers["strictTransportSecurity"].ObjectValue()
+	if got := hsts["accessControlMaxAgeSec"].NumberValue(); got != 63072000 {
+		t.Errorf("HSTS max-age = %v, want 63072000", got)
+	}
+	if got := headers["frameOptions"].ObjectValue()["frameOption"].StringValue(); got != "SAMEORIGIN" {
+		t.Errorf("frameOption = %s, want SAMEORIGIN", got)
+	}
+	if got := headers["contentSecurityPolicy"].ObjectValue()["contentSecurityPolicy"].StringValue(); got != "default-src 'self'" {
+		t.Errorf("CSP = %s, want default-src 'self'", got)
+	}
+
+	distribution := mocks.ByType(distributionToken)[0]
+	behavior := distribution.Inputs["defaultCacheBehavior"].ObjectValue()
+	if behavior["responseHeadersPolicyId"].IsNull() {
+		t.Error("default behavior does not reference the security headers policy")
+	}
+}
+
+func TestSecurityHeadersConflictsWithPolicyId(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.SecurityHeaders = &SecurityHeadersConfig{}
+		config.ResponseHeadersPolicyId = "67f7725c-6f97-4210-82d7-5512b31e9d03"
+		_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected SecurityHeaders with an explicit policy id to be rejected")
+	}
+}
@@ -0,0 +1,37 @@
+package cloudfront
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestEnumValidation(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(*CloudFrontConfig)
+	}{
+		{"price class", func(c *CloudFrontConfig) { c.PriceClass = "PriceClass_50" }},
+		{"viewer protocol policy", func(c *CloudFrontConfig) { c.ViewerProtocolPolicy = "redirect" }},
+		{"origin protocol policy", func(c *CloudFrontConfig) { c.OriginProtocolPolicy = "tcp-only" }},
+		{"behavior viewer protocol policy", func(c *CloudFrontConfig) {
+			c.Origins = []OriginConfig{{OriginId: "app", DomainName: "app.example.com"}}
+			c.OrderedCacheBehaviors = []CacheBehaviorConfig{
+				{PathPattern: "/api/*", TargetOriginId: "app", ViewerProtocolPolicy: "both"},
+			}
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				config := testConfig()
+				tc.mutate(config)
+				_, err := NewCloudFrontDistribution(ctx, "cdn", config)
+				return err
+			}, pulumi.WithMocks("project", "stack", &testMocks{}))
+			if err == nil {
+				t.Fatal("expected invalid enum value to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package composite
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/internal/testmocks"
+)
+
+// testMocks is the shared recorder; this package needs no extra
+// synthesized outputs.
+type testMocks struct {
+	testmocks.Recorder
+}
+
+// runTest executes a Pulumi program against the mock monitor and fails
+// the test if the program errors.
+func runTest(t *testing.T, program pulumi.RunFunc) *testMocks {
+	t.Helper()
+	mocks := &testMocks{}
+	testmocks.Run(t, mocks, program)
+	return mocks
+}
@@ -0,0 +1,160 @@
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/apigateway"
+	"github.com/denecloud/pulumi-constructs/lambda"
+)
+
+// RouteConfig maps one API route to the Lambda function handling it.
+type RouteConfig struct {
+	// Path is the route path, e.g. "/users".
+	Path string
+	// Method is the HTTP method, e.g. "GET".
+	Method string
+	// Function configures the Lambda function created for this route.
+	// Its Environment defaults to the composite's.
+	Function *lambda.LambdaConfig
+}
+
+// ServerlessAPIConfig configures a ServerlessAPI component.
+type ServerlessAPIConfig struct {
+	// Environment tags every resource, e.g. "dev" or "prod".
+	Environment string
+	// Routes define the API surface; a LambdaFunction component is
+	// created per route and wired into the matching endpoint.
+	Routes []RouteConfig
+	// EnableCORS adds OPTIONS mock integrations and CORS headers.
+	EnableCORS bool
+	// CORS customizes the CORS headers; implies EnableCORS.
+	CORS *apigateway.CORSConfig
+	// CustomDomain optionally attaches a custom domain to the API.
+	CustomDomain *apigateway.CustomDomainConfig
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// Validate checks the config for errors that would otherwise surface
+// as opaque AWS API failures. All violations are reported at once.
+func (c *ServerlessAPIConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	if len(c.Routes) == 0 {
+		errs = append(errs, fmt.Errorf("at least one route is required"))
+	}
+	for i, route := range c.Routes {
+		if !strings.HasPrefix(route.Path, "/") {
+			errs = append(errs, fmt.Errorf("Routes[%d]: Path must start with /, got %q", i, route.Path))
+		}
+		if route.Method == "" {
+			errs = append(errs, fmt.Errorf("Routes[%d]: Method is required", i))
+		}
+		if route.Function == nil {
+			errs = append(errs, fmt.Errorf("Routes[%d]: Function is required", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ServerlessAPI is an API Gateway REST API whose endpoints are each
+// backed by a Lambda function created from the route definitions.
+type ServerlessAPI struct {
+	pulumi.ResourceState
+
+	// API is the composed API Gateway component.
+	API *apigateway.APIGateway
+	// Functions maps each route, keyed "METHOD /path", to its Lambda
+	// component.
+	Functions map[string]*lambda.LambdaFunction
+	// RouteFunctionArns maps each route, keyed "METHOD /path", to the
+	// handling function's ARN.
+	RouteFunctionArns map[string]pulumi.StringOutput
+	// APIURL is the invoke URL of the deployed stage.
+	APIURL pulumi.StringOutput
+}
+
+// NewServerlessAPI creates a Lambda function per route and wires them
+// into an API Gateway with the matching endpoints.
+func NewServerlessAPI(ctx *pulumi.Context, name string, config *ServerlessAPIConfig, opts ...pulumi.ResourceOption) (*ServerlessAPI, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &ServerlessAPI{}
+	err := ctx.RegisterComponentResource("denecloud:aws:ServerlessAPI", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	childOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child components only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the composite itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		childOpts = append(childOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	comp.Functions = make(map[string]*lambda.LambdaFunction, len(config.Routes))
+	comp.RouteFunctionArns = make(map[string]pulumi.StringOutput, len(config.Routes))
+	endpoints := make([]apigateway.EndpointConfig, 0, len(config.Routes))
+	for _, route := range config.Routes {
+		routeKey := fmt.Sprintf("%s %s", route.Method, route.Path)
+		functionConfig := *route.Function
+		if functionConfig.Environment == "" {
+			functionConfig.Environment = config.Environment
+		}
+		function, err := lambda.NewLambdaFunction(ctx, routeResourceName(name, route), &functionConfig, childOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("route %s: %w", routeKey, err)
+		}
+		comp.Functions[routeKey] = function
+		comp.RouteFunctionArns[routeKey] = function.FunctionArn
+		endpoints = append(endpoints, apigateway.EndpointConfig{
+			Path:           route.Path,
+			Method:         route.Method,
+			LambdaFunction: function.Function,
+		})
+	}
+
+	api, err := apigateway.NewAPIGateway(ctx, name, &apigateway.APIGatewayConfig{
+		Environment:  config.Environment,
+		Endpoints:    endpoints,
+		EnableCORS:   config.EnableCORS,
+		CORS:         config.CORS,
+		CustomDomain: config.CustomDomain,
+		Tags:         config.Tags,
+	}, childOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.API = api
+	comp.APIURL = api.BaseURL
+
+	if err := ctx.RegisterResourceOutputs(comp, pulumi.Map{
+		"apiUrl": comp.APIURL,
+	}); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
+
+// routeResourceName derives a stable child resource name from a route,
+// e.g. "api-get-users" for GET /users.
+func routeResourceName(name string, route RouteConfig) string {
+	path := strings.Trim(route.Path, "/")
+	path = strings.NewReplacer("/", "-", "{", "", "}", "").Replace(path)
+	if path == "" {
+		path = "root"
+	}
+	return fmt.Sprintf("%s-%s-%s", name, strings.ToLower(route.Method), path)
+}
@@ -0,0 +1,65 @@
+package composite
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/lambda"
+)
+
+func TestServerlessAPIWiresTwoRoutes(t *testing.T) {
+	routeKeys := 0
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		api, err := NewServerlessAPI(ctx, "api", &ServerlessAPIConfig{
+			Environment: "dev",
+			Routes: []RouteConfig{
+				{Path: "/users", Method: "GET", Function: &lambda.LambdaConfig{
+					Runtime: "go1.x",
+					Handler: "list",
+					Code:    pulumi.NewFileArchive("testdata/handler.zip"),
+				}},
+				{Path: "/users", Method: "POST", Function: &lambda.LambdaConfig{
+					Runtime: "go1.x",
+					Handler: "create",
+					Code:    pulumi.NewFileArchive("testdata/handler.zip"),
+				}},
+			},
+		})
+		if err != nil {
+			return err
+		}
+		routeKeys = len(api.RouteFunctionArns)
+		for key := range api.RouteFunctionArns {
+			if _, ok := api.Functions[key]; !ok {
+				t.Errorf("route %q has an ARN but no function component", key)
+			}
+		}
+		return nil
+	})
+
+	if functions := mocks.ByType("aws:lambda/function:Function"); len(functions) != 2 {
+		t.Fatalf("expected 2 functions, got %d", len(functions))
+	}
+	methods := mocks.ByType("aws:apigateway/method:Method")
+	seen := map[string]bool{}
+	for _, method := range methods {
+		seen[method.Inputs["httpMethod"].StringValue()] = true
+	}
+	if !seen["GET"] || !seen["POST"] {
+		t.Errorf("expected GET and POST methods, got %v", seen)
+	}
+	if routeKeys != 2 {
+		t.Errorf("expected 2 route ARNs, got %d", routeKeys)
+	}
+}
+
+func TestServerlessAPIRequiresRoutes(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewServerlessAPI(ctx, "api", &ServerlessAPIConfig{Environment: "dev"})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a serverless API without routes to be rejected")
+	}
+}
@@ -0,0 +1,179 @@
+// Package composite provides higher-level constructs that compose the
+// base components into common serverless architectures.
+package composite
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/route53"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/cloudfront"
+	"github.com/denecloud/pulumi-constructs/s3"
+)
+
+// StaticSiteConfig configures a StaticSite component.
+type StaticSiteConfig struct {
+	// Environment tags every resource, e.g. "dev" or "prod".
+	Environment string
+	// IndexDocument is served for the root URL and, for SPAs, rewritten
+	// error routes. Defaults to "index.html".
+	IndexDocument string
+	// SPA rewrites the 403 and 404 errors a private bucket returns for
+	// unknown keys into the index document with a 200, so client-side
+	// routes resolve on deep links.
+	SPA bool
+	// BucketName optionally sets an explicit bucket name.
+	BucketName string
+	// DomainName is an optional custom domain for the site.
+	DomainName string
+	// CertificateArn is the us-east-1 ACM certificate for DomainName.
+	// When empty a certificate is created and, with Route53ZoneId, DNS
+	// validated.
+	CertificateArn string
+	// Route53ZoneId is the hosted zone receiving the alias records for
+	// DomainName and any certificate validation records.
+	Route53ZoneId string
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// Validate checks the config for errors that would otherwise surface
+// as opaque AWS API failures. All violations are reported at once.
+func (c *StaticSiteConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	if c.DomainName == "" && (c.CertificateArn != "" || c.Route53ZoneId != "") {
+		errs = append(errs, fmt.Errorf("CertificateArn and Route53ZoneId require a DomainName"))
+	}
+	return errors.Join(errs...)
+}
+
+// StaticSite is a private S3 bucket served through CloudFront with an
+// origin access control: the bucket policy only admits reads signed by
+// the distribution.
+type StaticSite struct {
+	pulumi.ResourceState
+
+	// Bucket holds the site content. Private; only CloudFront reads it.
+	Bucket *s3.SecureBucket
+	// Distribution serves the bucket.
+	Distribution *cloudfront.CloudFrontDistribution
+	// SiteURL is the https URL of the site: the custom domain when one
+	// is configured, the *.cloudfront.net domain otherwise.
+	SiteURL pulumi.StringOutput
+}
+
+// NewStaticSite composes a SecureBucket, a CloudFront distribution
+// with an S3 origin access control, and the bucket policy linking the
+// two.
+func NewStaticSite(ctx *pulumi.Context, name string, config *StaticSiteConfig, opts ...pulumi.ResourceOption) (*StaticSite, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &StaticSite{}
+	err := ctx.RegisterComponentResource("denecloud:aws:StaticSite", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	childOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child components only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the composite itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		childOpts = append(childOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	bucket, err := s3.NewSecureBucket(ctx, name, &s3.BucketConfig{
+		Environment: config.Environment,
+		BucketName:  config.BucketName,
+		Tags:        config.Tags,
+	}, childOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Bucket = bucket
+
+	indexDocument := config.IndexDocument
+	if indexDocument == "" {
+		indexDocument = "index.html"
+	}
+
+	distributionConfig := &cloudfront.CloudFrontConfig{
+		Environment: config.Environment,
+		Origins: []cloudfront.OriginConfig{{
+			OriginId:     name + "-s3",
+			OriginDomain: bucket.BucketRegionalDomainName,
+			OriginType:   "s3",
+		}},
+		DefaultRootObject: indexDocument,
+		Tags:              config.Tags,
+	}
+	if config.SPA {
+		// A private bucket answers 403 for unknown keys (404 with
+		// ListBucket permission); rewrite both to the SPA entry point.
+		for _, errorCode := range []int{403, 404} {
+			distributionConfig.CustomErrorResponses = append(distributionConfig.CustomErrorResponses, cloudfront.ErrorResponseConfig{
+				ErrorCode:        errorCode,
+				ResponseCode:     200,
+				ResponsePagePath: "/" + indexDocument,
+			})
+		}
+	}
+	if config.DomainName != "" {
+		distributionConfig.Aliases = []string{config.DomainName}
+		distributionConfig.CertificateArn = config.CertificateArn
+		distributionConfig.Route53ZoneId = config.Route53ZoneId
+	}
+	distribution, err := cloudfront.NewCloudFrontDistribution(ctx, name, distributionConfig, childOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Distribution = distribution
+
+	if err := s3.GrantCloudFrontOAC(ctx, name, bucket, distribution.Distribution.Arn, ""); err != nil {
+		return nil, err
+	}
+
+	if config.DomainName != "" && config.Route53ZoneId != "" {
+		for _, recordType := range []string{"A", "AAAA"} {
+			_, err := route53.NewRecord(ctx, fmt.Sprintf("%s-%s", name, strings.ToLower(recordType)), &route53.RecordArgs{
+				Name:   pulumi.String(config.DomainName),
+				Type:   pulumi.String(recordType),
+				ZoneId: pulumi.String(config.Route53ZoneId),
+				Aliases: route53.RecordAliasArray{
+					&route53.RecordAliasArgs{
+						Name:                 distribution.DomainName,
+						ZoneId:               distribution.Distribution.HostedZoneId,
+						EvaluateTargetHealth: pulumi.Bool(false),
+					},
+				},
+			}, childOpts...)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if config.DomainName != "" {
+		comp.SiteURL = pulumi.String("https://" + config.DomainName).ToStringOutput()
+	} else {
+		comp.SiteURL = pulumi.Sprintf("https://%s", distribution.DomainName)
+	}
+
+	if err := ctx.RegisterResourceOutputs(comp, pulumi.Map{
+		"siteUrl": comp.SiteURL,
+	}); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
@@ -0,0 +1,94 @@
+package composite
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestStaticSiteComposesBucketDistributionAndPolicy(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewStaticSite(ctx, "site", &StaticSiteConfig{
+			Environment: "dev",
+			SPA:         true,
+		})
+		return err
+	})
+
+	if buckets := mocks.ByType("aws:s3/bucketV2:BucketV2"); len(buckets) != 1 {
+		t.Fatalf("expected 1 bucket, got %d", len(buckets))
+	}
+	if oacs := mocks.ByType("aws:cloudfront/originAccessControl:OriginAccessControl"); len(oacs) != 1 {
+		t.Fatalf("expected 1 origin access control, got %d", len(oacs))
+	}
+	distributions := mocks.ByType("aws:cloudfront/distribution:Distribution")
+	if len(distributions) != 1 {
+		t.Fatalf("expected 1 distribution, got %d", len(distributions))
+	}
+	errorResponses := distributions[0].Inputs["customErrorResponses"].ArrayValue()
+	if len(errorResponses) != 2 {
+		t.Fatalf("expected 403 and 404 rewrites, got %d", len(errorResponses))
+	}
+	for _, response := range errorResponses {
+		rewrite := response.ObjectValue()
+		if got := rewrite["responseCode"].NumberValue(); got != 200 {
+			t.Errorf("responseCode = %v, want 200", got)
+		}
+		if got := rewrite["responsePagePath"].StringValue(); got != "/index.html" {
+			t.Errorf("responsePagePath = %s, want /index.html", got)
+		}
+	}
+
+	policies := mocks.ByType("aws:s3/bucketPolicy:BucketPolicy")
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 bucket policy, got %d", len(policies))
+	}
+	policy := policies[0].Inputs["policy"].StringValue()
+	if !strings.Contains(policy, "cloudfront.amazonaws.com") {
+		t.Errorf("bucket policy does not grant the CloudFront service principal: %s", policy)
+	}
+	if !strings.Contains(policy, "AWS:SourceArn") {
+		t.Errorf("bucket policy is not conditioned on the distribution ARN: %s", policy)
+	}
+}
+
+func TestStaticSiteCustomDomainCreatesAliasRecords(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewStaticSite(ctx, "site", &StaticSiteConfig{
+			Environment:    "dev",
+			DomainName:     "www.example.com",
+			CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+			Route53ZoneId:  "Z123456",
+		})
+		return err
+	})
+
+	records := mocks.ByType("aws:route53/record:Record")
+	if len(records) != 2 {
+		t.Fatalf("expected A and AAAA records, got %d", len(records))
+	}
+	for _, record := range records {
+		if got := record.Inputs["name"].StringValue(); got != "www.example.com" {
+			t.Errorf("record name = %s, want www.example.com", got)
+		}
+	}
+	distribution := mocks.ByType("aws:cloudfront/distribution:Distribution")[0]
+	aliases := distribution.Inputs["aliases"].ArrayValue()
+	if len(aliases) != 1 || aliases[0].StringValue() != "www.example.com" {
+		t.Errorf("aliases = %v, want [www.example.com]", aliases)
+	}
+}
+
+func TestStaticSiteCertificateRequiresDomain(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewStaticSite(ctx, "site", &StaticSiteConfig{
+			Environment:    "dev",
+			CertificateArn: "arn:aws:acm:us-east-1:123456789012:certificate/abc",
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a certificate without a domain name to be rejected")
+	}
+}
@@ -0,0 +1,293 @@
+// Package dynamodb provides a reusable Pulumi component for DynamoDB
+// tables with secondary indexes, recovery and stream defaults.
+package dynamodb
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/dynamodb"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// AttributeConfig declares a key attribute by name and DynamoDB type.
+type AttributeConfig struct {
+	// Name is the attribute name.
+	Name string
+	// Type is "S" (string), "N" (number) or "B" (binary).
+	Type string
+}
+
+// GlobalSecondaryIndexConfig adds a GSI to the table. Its key
+// attributes must be declared on the table via PartitionKey, SortKey
+// or Attributes.
+type GlobalSecondaryIndexConfig struct {
+	// Name is the index name.
+	Name string
+	// PartitionKey is the index hash key attribute name.
+	PartitionKey string
+	// SortKey is the optional index range key attribute name.
+	SortKey string
+	// ProjectionType is ALL (default), KEYS_ONLY or INCLUDE.
+	ProjectionType string
+	// NonKeyAttributes are projected for the INCLUDE projection type.
+	NonKeyAttributes []string
+	// ReadCapacity and WriteCapacity size the index under PROVISIONED
+	// billing. Ignored for PAY_PER_REQUEST.
+	ReadCapacity  int
+	WriteCapacity int
+}
+
+// TableConfig configures a Table component.
+type TableConfig struct {
+	// Environment tags every resource, e.g. "dev" or "prod".
+	Environment string
+	// PartitionKey is the table hash key. Required.
+	PartitionKey AttributeConfig
+	// SortKey is the optional table range key.
+	SortKey *AttributeConfig
+	// Attributes declares additional attributes referenced by secondary
+	// index keys.
+	Attributes []AttributeConfig
+	// BillingMode is PAY_PER_REQUEST (default) or PROVISIONED.
+	BillingMode string
+	// ReadCapacity and WriteCapacity size the table under PROVISIONED
+	// billing. Required in that mode.
+	ReadCapacity  int
+	WriteCapacity int
+	// GlobalSecondaryIndexes are created alongside the table.
+	GlobalSecondaryIndexes []GlobalSecondaryIndexConfig
+	// PointInTimeRecovery enables continuous backups.
+	PointInTimeRecovery bool
+	// TTLAttribute names the epoch-seconds attribute DynamoDB expires
+	// items by. Empty disables TTL.
+	TTLAttribute string
+	// StreamViewType enables streams with the given record view:
+	// KEYS_ONLY, NEW_IMAGE, OLD_IMAGE or NEW_AND_OLD_IMAGES. Empty
+	// disables streams.
+	StreamViewType string
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// validAttributeTypes are the DynamoDB scalar key types.
+var validAttributeTypes = map[string]bool{"S": true, "N": true, "B": true}
+
+// Validate checks the config for errors that would otherwise surface
+// as opaque AWS API failures. All violations are reported at once.
+func (c *TableConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	declared := map[string]bool{}
+	checkAttribute := func(field string, attribute AttributeConfig) {
+		if attribute.Name == "" {
+			errs = append(errs, fmt.Errorf("%s: Name is required", field))
+		}
+		if !validAttributeTypes[attribute.Type] {
+			errs = append(errs, fmt.Errorf("%s: invalid Type %q: must be S, N or B", field, attribute.Type))
+		}
+		declared[attribute.Name] = true
+	}
+	checkAttribute("PartitionKey", c.PartitionKey)
+	if c.SortKey != nil {
+		checkAttribute("SortKey", *c.SortKey)
+	}
+	for i, attribute := range c.Attributes {
+		checkAttribute(fmt.Sprintf("Attributes[%d]", i), attribute)
+	}
+	switch c.BillingMode {
+	case "", "PAY_PER_REQUEST":
+		if c.ReadCapacity != 0 || c.WriteCapacity != 0 {
+			errs = append(errs, fmt.Errorf("ReadCapacity and WriteCapacity require the PROVISIONED billing mode"))
+		}
+	case "PROVISIONED":
+		if c.ReadCapacity <= 0 || c.WriteCapacity <= 0 {
+			errs = append(errs, fmt.Errorf("PROVISIONED billing requires positive ReadCapacity and WriteCapacity"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("invalid BillingMode %q: must be PAY_PER_REQUEST or PROVISIONED", c.BillingMode))
+	}
+	for i, index := range c.GlobalSecondaryIndexes {
+		if index.Name == "" {
+			errs = append(errs, fmt.Errorf("GlobalSecondaryIndexes[%d]: Name is required", i))
+		}
+		if index.PartitionKey == "" {
+			errs = append(errs, fmt.Errorf("GlobalSecondaryIndexes[%d]: PartitionKey is required", i))
+		} else if !declared[index.PartitionKey] {
+			errs = append(errs, fmt.Errorf("GlobalSecondaryIndexes[%d]: PartitionKey %q is not a declared attribute", i, index.PartitionKey))
+		}
+		if index.SortKey != "" && !declared[index.SortKey] {
+			errs = append(errs, fmt.Errorf("GlobalSecondaryIndexes[%d]: SortKey %q is not a declared attribute", i, index.SortKey))
+		}
+		switch index.ProjectionType {
+		case "", "ALL", "KEYS_ONLY":
+			if len(index.NonKeyAttributes) > 0 {
+				errs = append(errs, fmt.Errorf("GlobalSecondaryIndexes[%d]: NonKeyAttributes require the INCLUDE projection type", i))
+			}
+		case "INCLUDE":
+			if len(index.NonKeyAttributes) == 0 {
+				errs = append(errs, fmt.Errorf("GlobalSecondaryIndexes[%d]: INCLUDE projection requires NonKeyAttributes", i))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("GlobalSecondaryIndexes[%d]: invalid ProjectionType %q: must be ALL, KEYS_ONLY or INCLUDE", i, index.ProjectionType))
+		}
+	}
+	switch c.StreamViewType {
+	case "", "KEYS_ONLY", "NEW_IMAGE", "OLD_IMAGE", "NEW_AND_OLD_IMAGES":
+	default:
+		errs = append(errs, fmt.Errorf("invalid StreamViewType %q: must be KEYS_ONLY, NEW_IMAGE, OLD_IMAGE or NEW_AND_OLD_IMAGES", c.StreamViewType))
+	}
+	return errors.Join(errs...)
+}
+
+// Table is a DynamoDB table with its indexes and, when streams are
+// enabled, a stream ARN ready for a Lambda event source mapping.
+type Table struct {
+	pulumi.ResourceState
+
+	// Table is the underlying DynamoDB table resource.
+	Table *dynamodb.Table
+	// TableArn is the table ARN.
+	TableArn pulumi.StringOutput
+	// TableName is the generated table name.
+	TableName pulumi.StringOutput
+	// StreamArn is the table stream ARN. Unset unless StreamViewType
+	// is given.
+	StreamArn pulumi.StringOutput
+}
+
+// NewTable creates a DynamoDB table from the given config.
+func NewTable(ctx *pulumi.Context, name string, config *TableConfig, opts ...pulumi.ResourceOption) (*Table, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &Table{}
+	err := ctx.RegisterComponentResource("denecloud:aws:Table", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	resourceTags := tags.MergeTags(config.Environment, config.Tags)
+
+	attributes := dynamodb.TableAttributeArray{}
+	appendAttribute := func(attribute AttributeConfig) {
+		attributes = append(attributes, &dynamodb.TableAttributeArgs{
+			Name: pulumi.String(attribute.Name),
+			Type: pulumi.String(attribute.Type),
+		})
+	}
+	appendAttribute(config.PartitionKey)
+	if config.SortKey != nil {
+		appendAttribute(*config.SortKey)
+	}
+	for _, attribute := range config.Attributes {
+		appendAttribute(attribute)
+	}
+
+	billingMode := config.BillingMode
+	if billingMode == "" {
+		billingMode = "PAY_PER_REQUEST"
+	}
+
+	tableArgs := &dynamodb.TableArgs{
+		HashKey:     pulumi.String(config.PartitionKey.Name),
+		Attributes:  attributes,
+		BillingMode: pulumi.String(billingMode),
+		Tags:        resourceTags,
+	}
+	if config.SortKey != nil {
+		tableArgs.RangeKey = pulumi.String(config.SortKey.Name)
+	}
+	if billingMode == "PROVISIONED" {
+		tableArgs.ReadCapacity = pulumi.Int(config.ReadCapacity)
+		tableArgs.WriteCapacity = pulumi.Int(config.WriteCapacity)
+	}
+	if len(config.GlobalSecondaryIndexes) > 0 {
+		indexes := dynamodb.TableGlobalSecondaryIndexArray{}
+		for _, index := range config.GlobalSecondaryIndexes {
+			projectionType := index.ProjectionType
+			if projectionType == "" {
+				projectionType = "ALL"
+			}
+			indexArgs := &dynamodb.TableGlobalSecondaryIndexArgs{
+				Name:           pulumi.String(index.Name),
+				HashKey:        pulumi.String(index.PartitionKey),
+				ProjectionType: pulumi.String(projectionType),
+			}
+			if index.SortKey != "" {
+				indexArgs.RangeKey = pulumi.String(index.SortKey)
+			}
+			if len(index.NonKeyAttributes) > 0 {
+				indexArgs.NonKeyAttributes = pulumi.ToStringArray(index.NonKeyAttributes)
+			}
+			if billingMode == "PROVISIONED" {
+				readCapacity := index.ReadCapacity
+				if readCapacity == 0 {
+					readCapacity = config.ReadCapacity
+				}
+				writeCapacity := index.WriteCapacity
+				if writeCapacity == 0 {
+					writeCapacity = config.WriteCapacity
+				}
+				indexArgs.ReadCapacity = pulumi.Int(readCapacity)
+				indexArgs.WriteCapacity = pulumi.Int(writeCapacity)
+			}
+			indexes = append(indexes, indexArgs)
+		}
+		tableArgs.GlobalSecondaryIndexes = indexes
+	}
+	if config.PointInTimeRecovery {
+		tableArgs.PointInTimeRecovery = &dynamodb.TablePointInTimeRecoveryArgs{
+			Enabled: pulumi.Bool(true),
+		}
+	}
+	if config.TTLAttribute != "" {
+		tableArgs.Ttl = &dynamodb.TableTtlArgs{
+			AttributeName: pulumi.String(config.TTLAttribute),
+			Enabled:       pulumi.Bool(true),
+		}
+	}
+	if config.StreamViewType != "" {
+		tableArgs.StreamEnabled = pulumi.Bool(true)
+		tableArgs.StreamViewType = pulumi.String(config.StreamViewType)
+	}
+
+	table, err := dynamodb.NewTable(ctx, name, tableArgs, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Table = table
+	comp.TableArn = table.Arn
+	comp.TableName = table.Name
+	if config.StreamViewType != "" {
+		comp.StreamArn = table.StreamArn
+	}
+
+	outputs := pulumi.Map{
+		"tableArn":  comp.TableArn,
+		"tableName": comp.TableName,
+	}
+	if comp.StreamArn != (pulumi.StringOutput{}) {
+		outputs["streamArn"] = comp.StreamArn
+	}
+	if err := ctx.RegisterResourceOutputs(comp, outputs); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
@@ -0,0 +1,101 @@
+package dynamodb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const tableToken = "aws:dynamodb/table:Table"
+
+func TestTableWithGlobalSecondaryIndex(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.SortKey = &AttributeConfig{Name: "sk", Type: "S"}
+		config.Attributes = []AttributeConfig{{Name: "status", Type: "S"}}
+		config.GlobalSecondaryIndexes = []GlobalSecondaryIndexConfig{
+			{Name: "by-status", PartitionKey: "status", SortKey: "sk"},
+		}
+		_, err := NewTable(ctx, "orders", config)
+		return err
+	})
+
+	tables := mocks.ByType(tableToken)
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(tables))
+	}
+	table := tables[0]
+	if got := table.Inputs["hashKey"].StringValue(); got != "pk" {
+		t.Errorf("hashKey = %s, want pk", got)
+	}
+	if got := len(table.Inputs["attributes"].ArrayValue()); got != 3 {
+		t.Errorf("expected 3 declared attributes, got %d", got)
+	}
+	indexes := table.Inputs["globalSecondaryIndexes"].ArrayValue()
+	if len(indexes) != 1 {
+		t.Fatalf("expected 1 GSI, got %d", len(indexes))
+	}
+	index := indexes[0].ObjectValue()
+	if got := index["name"].StringValue(); got != "by-status" {
+		t.Errorf("index name = %s, want by-status", got)
+	}
+	if got := index["hashKey"].StringValue(); got != "status" {
+		t.Errorf("index hashKey = %s, want status", got)
+	}
+	if got := index["projectionType"].StringValue(); got != "ALL" {
+		t.Errorf("index projectionType = %s, want ALL", got)
+	}
+}
+
+func TestStreamArnExportedWhenEnabled(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.StreamViewType = "NEW_AND_OLD_IMAGES"
+		table, err := NewTable(ctx, "orders", config)
+		if err != nil {
+			return err
+		}
+		table.StreamArn.ApplyT(func(arn string) string {
+			if !strings.Contains(arn, "/stream/") {
+				t.Errorf("StreamArn = %q, want a stream ARN", arn)
+			}
+			return arn
+		})
+		return nil
+	})
+
+	table := mocks.ByType(tableToken)[0]
+	if !table.Inputs["streamEnabled"].BoolValue() {
+		t.Error("streamEnabled not set")
+	}
+	if got := table.Inputs["streamViewType"].StringValue(); got != "NEW_AND_OLD_IMAGES" {
+		t.Errorf("streamViewType = %s, want NEW_AND_OLD_IMAGES", got)
+	}
+}
+
+func TestGSIKeyMustBeDeclared(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.GlobalSecondaryIndexes = []GlobalSecondaryIndexConfig{
+			{Name: "by-status", PartitionKey: "status"},
+		}
+		_, err := NewTable(ctx, "orders", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a GSI keyed on an undeclared attribute to be rejected")
+	}
+}
+
+func TestProvisionedBillingRequiresCapacity(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.BillingMode = "PROVISIONED"
+		_, err := NewTable(ctx, "orders", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected PROVISIONED billing without capacity to be rejected")
+	}
+}
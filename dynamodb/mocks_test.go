@@ -0,0 +1,41 @@
+package dynamodb
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/internal/testmocks"
+)
+
+// testMocks wraps the shared recorder and synthesizes the stream ARN
+// the component reads off tables with streams enabled.
+type testMocks struct {
+	testmocks.Recorder
+}
+
+func (m *testMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, outputs, err := m.Recorder.NewResource(args)
+	if args.TypeToken == tableToken {
+		outputs["streamArn"] = resource.NewStringProperty("arn:aws:dynamodb:us-east-1:123456789012:table/" + args.Name + "/stream/2026-01-01T00:00:00.000")
+	}
+	return id, outputs, err
+}
+
+// runTest executes a Pulumi program against the mock monitor and fails
+// the test if the program errors.
+func runTest(t *testing.T, program pulumi.RunFunc) *testMocks {
+	t.Helper()
+	mocks := &testMocks{}
+	testmocks.Run(t, mocks, program)
+	return mocks
+}
+
+// testConfig returns a minimal valid config for tests to extend.
+func testConfig() *TableConfig {
+	return &TableConfig{
+		Environment:  "dev",
+		PartitionKey: AttributeConfig{Name: "pk", Type: "S"},
+	}
+}
@@ -0,0 +1,89 @@
+package dynamodb
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Option mutates a TableConfig. Options are applied in order, so later
+// options override earlier ones.
+type Option func(*TableConfig)
+
+// WithEnvironment sets the environment tag, e.g. "dev" or "prod".
+func WithEnvironment(environment string) Option {
+	return func(c *TableConfig) { c.Environment = environment }
+}
+
+// WithPartitionKey sets the table hash key.
+func WithPartitionKey(name, attributeType string) Option {
+	return func(c *TableConfig) {
+		c.PartitionKey = AttributeConfig{Name: name, Type: attributeType}
+	}
+}
+
+// WithSortKey sets the table range key.
+func WithSortKey(name, attributeType string) Option {
+	return func(c *TableConfig) {
+		c.SortKey = &AttributeConfig{Name: name, Type: attributeType}
+	}
+}
+
+// WithProvisionedCapacity switches to PROVISIONED billing with the
+// given throughput.
+func WithProvisionedCapacity(read, write int) Option {
+	return func(c *TableConfig) {
+		c.BillingMode = "PROVISIONED"
+		c.ReadCapacity = read
+		c.WriteCapacity = write
+	}
+}
+
+// WithGlobalSecondaryIndex appends a GSI. Its key attributes must be
+// declared via WithAttribute or the table keys.
+func WithGlobalSecondaryIndex(index GlobalSecondaryIndexConfig) Option {
+	return func(c *TableConfig) {
+		c.GlobalSecondaryIndexes = append(c.GlobalSecondaryIndexes, index)
+	}
+}
+
+// WithAttribute declares an attribute for use in secondary index keys.
+func WithAttribute(name, attributeType string) Option {
+	return func(c *TableConfig) {
+		c.Attributes = append(c.Attributes, AttributeConfig{Name: name, Type: attributeType})
+	}
+}
+
+// WithPointInTimeRecovery enables continuous backups.
+func WithPointInTimeRecovery() Option {
+	return func(c *TableConfig) { c.PointInTimeRecovery = true }
+}
+
+// WithTTL expires items by the given epoch-seconds attribute.
+func WithTTL(attributeName string) Option {
+	return func(c *TableConfig) { c.TTLAttribute = attributeName }
+}
+
+// WithStream enables streams with the given record view, e.g.
+// NEW_AND_OLD_IMAGES.
+func WithStream(viewType string) Option {
+	return func(c *TableConfig) { c.StreamViewType = viewType }
+}
+
+// WithTags merges tags into the resource tag set.
+func WithTags(tags map[string]string) Option {
+	return func(c *TableConfig) {
+		if c.Tags == nil {
+			c.Tags = map[string]string{}
+		}
+		for k, v := range tags {
+			c.Tags[k] = v
+		}
+	}
+}
+
+// NewTableWithOptions builds a TableConfig from functional options and
+// creates the table. Equivalent to NewTable with a hand-built config.
+func NewTableWithOptions(ctx *pulumi.Context, name string, options ...Option) (*Table, error) {
+	config := &TableConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return NewTable(ctx, name, config)
+}
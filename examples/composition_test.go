@@ -0,0 +1,110 @@
+// Package examples demonstrates composing the apigateway, cloudfront,
+// lambda and s3 components in a single Pulumi program.
+package examples
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/apigateway"
+	"github.com/denecloud/pulumi-constructs/cloudfront"
+	"github.com/denecloud/pulumi-constructs/lambda"
+	"github.com/denecloud/pulumi-constructs/s3"
+)
+
+// exampleMocks is a minimal mock monitor synthesizing the outputs the
+// components read back during construction.
+type exampleMocks struct {
+	mu        sync.Mutex
+	resources []pulumi.MockResourceArgs
+}
+
+func (m *exampleMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	m.mu.Lock()
+	m.resources = append(m.resources, args)
+	m.mu.Unlock()
+	outputs := args.Inputs.Copy()
+	outputs["arn"] = resource.NewStringProperty("arn:aws:mock:us-east-1:123456789012:" + args.Name)
+	if args.TypeToken == "aws:lambda/function:Function" {
+		outputs["version"] = resource.NewStringProperty("1")
+	}
+	if args.TypeToken == "aws:cloudfront/distribution:Distribution" {
+		outputs["domainName"] = resource.NewStringProperty(args.Name + ".cloudfront.net")
+	}
+	return args.Name + "-id", outputs, nil
+}
+
+func (m *exampleMocks) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	return args.Args, nil
+}
+
+func (m *exampleMocks) count(token string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, r := range m.resources {
+		if r.TypeToken == token {
+			n++
+		}
+	}
+	return n
+}
+
+// TestComposeAllComponents builds one program using all four packages:
+// an S3 bucket for assets, a CloudFront distribution in front of it,
+// and a Lambda-backed REST API.
+func TestComposeAllComponents(t *testing.T) {
+	mocks := &exampleMocks{}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		assets, err := s3.NewSecureBucket(ctx, "assets", &s3.BucketConfig{
+			Environment: "dev",
+		})
+		if err != nil {
+			return err
+		}
+		_ = assets
+
+		if _, err := cloudfront.NewCloudFrontDistribution(ctx, "cdn", &cloudfront.CloudFrontConfig{
+			Environment:      "dev",
+			OriginDomainName: "assets.s3.us-east-1.amazonaws.com",
+			OriginType:       "s3",
+		}); err != nil {
+			return err
+		}
+
+		handler, err := lambda.NewLambdaFunction(ctx, "api-handler", &lambda.LambdaConfig{
+			Environment: "dev",
+			Runtime:     "go1.x",
+			Handler:     "main",
+			Code:        pulumi.NewFileArchive("handler.zip"),
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = apigateway.NewAPIGateway(ctx, "api", &apigateway.APIGatewayConfig{
+			Environment: "dev",
+			Endpoints: []apigateway.EndpointConfig{
+				{Path: "/items", Method: "GET", LambdaFunction: handler.Function},
+			},
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", mocks))
+	if err != nil {
+		t.Fatalf("composed program failed: %v", err)
+	}
+
+	for _, token := range []string{
+		"aws:s3/bucketV2:BucketV2",
+		"aws:cloudfront/distribution:Distribution",
+		"aws:lambda/function:Function",
+		"aws:apigateway/restApi:RestApi",
+	} {
+		if mocks.count(token) == 0 {
+			t.Errorf("expected at least one %s resource", token)
+		}
+	}
+}
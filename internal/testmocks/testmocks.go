@@ -0,0 +1,108 @@
+// Package testmocks provides the shared Pulumi mock harness used by
+// the component packages' unit tests. It records every registered
+// resource so tests can assert on resource types and inputs, and lets
+// tests inject a deterministic region and account id.
+package testmocks
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// Recorder is a pulumi.MockResourceMonitor that records resource
+// registrations and function calls. The zero value is ready to use.
+type Recorder struct {
+	mu        sync.Mutex
+	resources []pulumi.MockResourceArgs
+	calls     []pulumi.MockCallArgs
+
+	// Synthesize optionally adds provider-computed outputs for a
+	// resource on top of the echoed inputs, e.g. a function version or
+	// a distribution domain name.
+	Synthesize func(args pulumi.MockResourceArgs, outputs resource.PropertyMap)
+	// Region is returned for aws:index/getRegion lookups. Defaults to
+	// us-east-1.
+	Region string
+	// AccountID is returned for aws:index/getCallerIdentity lookups.
+	// Defaults to 123456789012.
+	AccountID string
+}
+
+func (m *Recorder) region() string {
+	if m.Region == "" {
+		return "us-east-1"
+	}
+	return m.Region
+}
+
+func (m *Recorder) accountID() string {
+	if m.AccountID == "" {
+		return "123456789012"
+	}
+	return m.AccountID
+}
+
+func (m *Recorder) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	m.mu.Lock()
+	m.resources = append(m.resources, args)
+	m.mu.Unlock()
+	outputs := args.Inputs.Copy()
+	outputs["arn"] = resource.NewStringProperty("arn:aws:mock:" + m.region() + ":" + m.accountID() + ":" + args.Name)
+	if m.Synthesize != nil {
+		m.Synthesize(args, outputs)
+	}
+	return args.Name + "-id", outputs, nil
+}
+
+func (m *Recorder) Call(args pulumi.MockCallArgs) (resource.PropertyMap, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, args)
+	m.mu.Unlock()
+	switch args.Token {
+	case "aws:index/getRegion:getRegion":
+		return resource.PropertyMap{"name": resource.NewStringProperty(m.region())}, nil
+	case "aws:index/getCallerIdentity:getCallerIdentity":
+		return resource.PropertyMap{"accountId": resource.NewStringProperty(m.accountID())}, nil
+	}
+	return args.Args, nil
+}
+
+// ByType returns all recorded resources with the given type token.
+func (m *Recorder) ByType(token string) []pulumi.MockResourceArgs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []pulumi.MockResourceArgs
+	for _, r := range m.resources {
+		if r.TypeToken == token {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// CallsByToken returns all recorded function calls with the given
+// invoke token.
+func (m *Recorder) CallsByToken(token string) []pulumi.MockCallArgs {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var matched []pulumi.MockCallArgs
+	for _, c := range m.calls {
+		if c.Token == token {
+			matched = append(matched, c)
+		}
+	}
+	return matched
+}
+
+// Run executes a Pulumi program against the mock monitor and fails
+// the test if the program errors. The monitor is typically a Recorder
+// or a package-local type embedding one.
+func Run(t *testing.T, mocks pulumi.MockResourceMonitor, program pulumi.RunFunc) {
+	t.Helper()
+	if err := pulumi.RunErr(program, pulumi.WithMocks("project", "stack", mocks)); err != nil {
+		t.Fatalf("pulumi program failed: %v", err)
+	}
+}
@@ -0,0 +1,108 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const alarmToken = "aws:cloudwatch/metricAlarm:MetricAlarm"
+
+func alarmsByMetric(mocks *testMocks) map[string]pulumi.MockResourceArgs {
+	byMetric := map[string]pulumi.MockResourceArgs{}
+	for _, a := range mocks.ByType(alarmToken) {
+		byMetric[a.Inputs["metricName"].StringValue()] = a
+	}
+	return byMetric
+}
+
+func TestThrottlesAlarmCreatedWhenThresholdSet(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Alerts = &AlertConfig{
+			ErrorsThreshold:    5,
+			ThrottlesThreshold: 10,
+			NotificationARN:    "arn:aws:sns:us-east-1:123456789012:alerts",
+		}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	byMetric := alarmsByMetric(mocks)
+	throttles, ok := byMetric["Throttles"]
+	if !ok {
+		t.Fatalf("no throttles alarm created, got metrics %v", byMetric)
+	}
+	if got := throttles.Inputs["statistic"].StringValue(); got != "Sum" {
+		t.Errorf("throttles statistic = %s, want Sum", got)
+	}
+	if got := throttles.Inputs["threshold"].NumberValue(); got != 10 {
+		t.Errorf("throttles threshold = %v, want 10", got)
+	}
+	actions := throttles.Inputs["alarmActions"].ArrayValue()
+	if len(actions) != 1 || actions[0].StringValue() != "arn:aws:sns:us-east-1:123456789012:alerts" {
+		t.Errorf("alarmActions = %v, want the notification ARN", actions)
+	}
+}
+
+func TestThrottlesAlarmOmittedWhenThresholdZero(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Alerts = &AlertConfig{ErrorsThreshold: 5}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	if _, ok := alarmsByMetric(mocks)["Throttles"]; ok {
+		t.Error("throttles alarm created despite zero threshold")
+	}
+}
+
+func TestErrorRateAlarmUsesMetricMath(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Alerts = &AlertConfig{ErrorRateThreshold: 2.5}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	var alarm *pulumi.MockResourceArgs
+	for _, a := range mocks.ByType(alarmToken) {
+		if _, ok := a.Inputs["metricQueries"]; ok {
+			alarm = &a
+			break
+		}
+	}
+	if alarm == nil {
+		t.Fatal("no metric-math alarm created")
+	}
+	if got := alarm.Inputs["threshold"].NumberValue(); got != 2.5 {
+		t.Errorf("threshold = %v, want 2.5", got)
+	}
+	queries := alarm.Inputs["metricQueries"].ArrayValue()
+	if len(queries) != 3 {
+		t.Fatalf("expected 3 metric queries, got %d", len(queries))
+	}
+	expressions := map[string]string{}
+	for _, q := range queries {
+		query := q.ObjectValue()
+		if expr, ok := query["expression"]; ok {
+			expressions[query["id"].StringValue()] = expr.StringValue()
+		}
+	}
+	if got := expressions["rate"]; got != "errors / invocations * 100" {
+		t.Errorf("rate expression = %q, want the error-rate metric math", got)
+	}
+}
+
+func TestErrorRateAboveHundredRejected(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Alerts = &AlertConfig{ErrorRateThreshold: 150}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected an error rate above 100 to be rejected")
+	}
+}
@@ -0,0 +1,59 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestAliasDisabled(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		createAlias := false
+		config.CreateAlias = &createAlias
+		fn, err := NewLambdaFunction(ctx, "handler", config)
+		if err != nil {
+			return err
+		}
+		if fn.Alias != nil {
+			t.Error("Alias set despite CreateAlias being disabled")
+		}
+		return nil
+	})
+
+	if aliases := mocks.ByType(aliasToken); len(aliases) != 0 {
+		t.Fatalf("expected no aliases, got %d", len(aliases))
+	}
+}
+
+func TestAliasRenamed(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.AliasName = "live"
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	aliases := mocks.ByType(aliasToken)
+	if len(aliases) != 1 {
+		t.Fatalf("expected 1 alias, got %d", len(aliases))
+	}
+	if got := aliases[0].Inputs["name"].StringValue(); got != "live" {
+		t.Errorf("alias name = %s, want live", got)
+	}
+}
+
+func TestProvisionedConcurrencyRequiresAlias(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		createAlias := false
+		config.CreateAlias = &createAlias
+		concurrency := 5
+		config.ProvisionedConcurrency = &concurrency
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected provisioned concurrency without an alias to be rejected")
+	}
+}
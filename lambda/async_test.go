@@ -0,0 +1,87 @@
+package lambda
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const invokeConfigToken = "aws:lambda/functionEventInvokeConfig:FunctionEventInvokeConfig"
+
+func TestAsyncDestinationsWired(t *testing.T) {
+	failureArn := "arn:aws:sqs:us-east-1:123456789012:failed"
+	successArn := "arn:aws:sns:us-east-1:123456789012:succeeded"
+	retries := 1
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Async = &AsyncConfig{
+			MaximumRetryAttempts:    &retries,
+			OnSuccessDestinationArn: successArn,
+			OnFailureDestinationArn: failureArn,
+		}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	configs := mocks.ByType(invokeConfigToken)
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 event invoke config, got %d", len(configs))
+	}
+	invoke := configs[0]
+	if got := invoke.Inputs["maximumRetryAttempts"].NumberValue(); got != 1 {
+		t.Errorf("maximumRetryAttempts = %v, want 1", got)
+	}
+	if got := invoke.Inputs["qualifier"].StringValue(); got != "prod" {
+		t.Errorf("qualifier = %s, want prod", got)
+	}
+	destinations := invoke.Inputs["destinationConfig"].ObjectValue()
+	if got := destinations["onSuccess"].ObjectValue()["destination"].StringValue(); got != successArn {
+		t.Errorf("onSuccess destination = %s, want %s", got, successArn)
+	}
+	if got := destinations["onFailure"].ObjectValue()["destination"].StringValue(); got != failureArn {
+		t.Errorf("onFailure destination = %s, want %s", got, failureArn)
+	}
+
+	sendGranted, publishGranted := false, false
+	for _, p := range mocks.ByType(rolePolicyToken) {
+		doc := p.Inputs["policy"].StringValue()
+		if strings.Contains(doc, "sqs:SendMessage") && strings.Contains(doc, failureArn) {
+			sendGranted = true
+		}
+		if strings.Contains(doc, "sns:Publish") && strings.Contains(doc, successArn) {
+			publishGranted = true
+		}
+	}
+	if !sendGranted {
+		t.Error("role cannot send to the on-failure queue")
+	}
+	if !publishGranted {
+		t.Error("role cannot publish to the on-success topic")
+	}
+}
+
+func TestAsyncConfigValidatesRanges(t *testing.T) {
+	badRetries := 3
+	badAge := 30
+	cases := []struct {
+		name  string
+		async *AsyncConfig
+	}{
+		{"retries too high", &AsyncConfig{MaximumRetryAttempts: &badRetries}},
+		{"event age too low", &AsyncConfig{MaximumEventAgeInSeconds: &badAge}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				config := testConfig()
+				config.Async = tc.async
+				_, err := NewLambdaFunction(ctx, "handler", config)
+				return err
+			}, pulumi.WithMocks("project", "stack", &testMocks{}))
+			if err == nil {
+				t.Fatal("expected async config to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestCodeSigningConfigArnAttached(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.CodeSigningConfigArn = "arn:aws:lambda:us-east-1:123456789012:code-signing-config:csc-abc"
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["codeSigningConfigArn"].StringValue(); got != "arn:aws:lambda:us-east-1:123456789012:code-signing-config:csc-abc" {
+		t.Errorf("codeSigningConfigArn = %s, want the configured ARN", got)
+	}
+}
+
+func TestSigningProfilesCreateCodeSigningConfig(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.SigningProfileArns = []string{"arn:aws:signer:us-east-1:123456789012:signing-profiles/release/v1"}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	configs := mocks.ByType("aws:lambda/codeSigningConfig:CodeSigningConfig")
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 code signing config, got %d", len(configs))
+	}
+	policies := configs[0].Inputs["policies"].ObjectValue()
+	if got := policies["untrustedArtifactOnDeployment"].StringValue(); got != "Enforce" {
+		t.Errorf("untrustedArtifactOnDeployment = %s, want Enforce", got)
+	}
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["codeSigningConfigArn"].StringValue(); got == "" {
+		t.Error("function does not reference the created code signing config")
+	}
+}
+
+func TestCodeSigningRejectedForImagePackages(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewLambdaFunction(ctx, "handler", &LambdaConfig{
+			Environment:          "dev",
+			PackageType:          "Image",
+			ImageUri:             "123456789012.dkr.ecr.us-east-1.amazonaws.com/app:latest",
+			CodeSigningConfigArn: "arn:aws:lambda:us-east-1:123456789012:code-signing-config:csc-abc",
+		})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected code signing with Image packaging to be rejected")
+	}
+}
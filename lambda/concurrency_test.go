@@ -0,0 +1,59 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const provisionedToken = "aws:lambda/provisionedConcurrencyConfig:ProvisionedConcurrencyConfig"
+
+func TestReservedConcurrencySetOnFunction(t *testing.T) {
+	reserved := 25
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ReservedConcurrentExecutions = &reserved
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["reservedConcurrentExecutions"].NumberValue(); got != 25 {
+		t.Errorf("reservedConcurrentExecutions = %v, want 25", got)
+	}
+}
+
+func TestReservedConcurrencyRejectsNegative(t *testing.T) {
+	reserved := -1
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ReservedConcurrentExecutions = &reserved
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected negative reserved concurrency to be rejected")
+	}
+}
+
+func TestProvisionedConcurrencyTargetsAlias(t *testing.T) {
+	provisioned := 5
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ProvisionedConcurrency = &provisioned
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	configs := mocks.ByType(provisionedToken)
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 provisioned concurrency config, got %d", len(configs))
+	}
+	pc := configs[0]
+	if got := pc.Inputs["provisionedConcurrentExecutions"].NumberValue(); got != 5 {
+		t.Errorf("provisionedConcurrentExecutions = %v, want 5", got)
+	}
+	if got := pc.Inputs["qualifier"].StringValue(); got != "prod" {
+		t.Errorf("qualifier = %s, want the prod alias", got)
+	}
+}
@@ -0,0 +1,33 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const dashboardToken = "aws:cloudwatch/dashboard:Dashboard"
+
+func TestDashboardCreatedOnlyWhenRequested(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewLambdaFunction(ctx, "handler", testConfig())
+		return err
+	})
+	if dashboards := mocks.ByType(dashboardToken); len(dashboards) != 0 {
+		t.Errorf("expected no dashboard without the flag, got %d", len(dashboards))
+	}
+
+	mocks = runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.CreateDashboard = true
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+	dashboards := mocks.ByType(dashboardToken)
+	if len(dashboards) != 1 {
+		t.Fatalf("expected 1 dashboard with the flag, got %d", len(dashboards))
+	}
+	if got := dashboards[0].Inputs["dashboardName"].StringValue(); got != "handler" {
+		t.Errorf("dashboard name = %s, want handler", got)
+	}
+}
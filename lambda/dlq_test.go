@@ -0,0 +1,80 @@
+package lambda
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const queueToken = "aws:sqs/queue:Queue"
+
+func TestDeadLetterBringYourOwnArn(t *testing.T) {
+	topicArn := "arn:aws:sns:us-east-1:123456789012:failures"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.DeadLetter = &DeadLetterConfig{TargetArn: topicArn}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	dlc := function.Inputs["deadLetterConfig"].ObjectValue()
+	if got := dlc["targetArn"].StringValue(); got != topicArn {
+		t.Errorf("deadLetterConfig.targetArn = %s, want %s", got, topicArn)
+	}
+	if queues := mocks.ByType(queueToken); len(queues) != 0 {
+		t.Errorf("expected no auto-created queue, got %d", len(queues))
+	}
+
+	policies := mocks.ByType("aws:iam/rolePolicy:RolePolicy")
+	var dlqPolicy string
+	for _, p := range policies {
+		doc := p.Inputs["policy"].StringValue()
+		if strings.Contains(doc, topicArn) {
+			dlqPolicy = doc
+		}
+	}
+	if dlqPolicy == "" {
+		t.Fatal("no role policy grants access to the dead-letter target")
+	}
+	if !strings.Contains(dlqPolicy, "sns:Publish") {
+		t.Errorf("SNS target policy should grant sns:Publish, got %s", dlqPolicy)
+	}
+}
+
+func TestDeadLetterAutoCreatesQueue(t *testing.T) {
+	var arn string
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.DeadLetter = &DeadLetterConfig{AutoCreateQueue: true}
+		fn, err := NewLambdaFunction(ctx, "handler", config)
+		if err != nil {
+			return err
+		}
+		fn.DeadLetterQueueArn.ApplyT(func(v string) string {
+			arn = v
+			return v
+		})
+		return nil
+	})
+
+	queues := mocks.ByType(queueToken)
+	if len(queues) != 1 {
+		t.Fatalf("expected 1 auto-created queue, got %d", len(queues))
+	}
+
+	found := false
+	for _, p := range mocks.ByType("aws:iam/rolePolicy:RolePolicy") {
+		if strings.Contains(p.Inputs["policy"].StringValue(), "sqs:SendMessage") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("no role policy grants sqs:SendMessage on the DLQ")
+	}
+
+	if arn == "" {
+		t.Error("DeadLetterQueueArn was not exported")
+	}
+}
@@ -0,0 +1,60 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const mappingToken = "aws:lambda/eventSourceMapping:EventSourceMapping"
+
+func TestSQSEventSourceMapping(t *testing.T) {
+	queueArn := "arn:aws:sqs:us-east-1:123456789012:jobs"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.EventSources = []EventSourceConfig{
+			{ARN: queueArn, BatchSize: 10, BatchWindowSeconds: 5},
+		}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	mappings := mocks.ByType(mappingToken)
+	if len(mappings) != 1 {
+		t.Fatalf("expected 1 event source mapping, got %d", len(mappings))
+	}
+	mapping := mappings[0]
+	if got := mapping.Inputs["eventSourceArn"].StringValue(); got != queueArn {
+		t.Errorf("eventSourceArn = %s, want %s", got, queueArn)
+	}
+	if got := mapping.Inputs["batchSize"].NumberValue(); got != 10 {
+		t.Errorf("batchSize = %v, want 10", got)
+	}
+	if got := mapping.Inputs["maximumBatchingWindowInSeconds"].NumberValue(); got != 5 {
+		t.Errorf("maximumBatchingWindowInSeconds = %v, want 5", got)
+	}
+
+	sqsPolicy := false
+	for _, a := range mocks.ByType("aws:iam/rolePolicyAttachment:RolePolicyAttachment") {
+		if a.Inputs["policyArn"].StringValue() == "arn:aws:iam::aws:policy/service-role/AWSLambdaSQSQueueExecutionRole" {
+			sqsPolicy = true
+		}
+	}
+	if !sqsPolicy {
+		t.Error("SQS execution role policy not attached for the poller")
+	}
+}
+
+func TestEventSourceRejectsUnknownService(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.EventSources = []EventSourceConfig{
+			{ARN: "arn:aws:s3:::bucket"},
+		}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected unsupported event source ARN to be rejected")
+	}
+}
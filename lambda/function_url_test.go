@@ -0,0 +1,84 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	functionURLToken = "aws:lambda/functionUrl:FunctionUrl"
+	permissionToken  = "aws:lambda/permission:Permission"
+)
+
+func TestPublicFunctionURLGrantsInvoke(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.FunctionURL = &FunctionURLConfig{
+			AuthType: "NONE",
+			CORS:     &FunctionURLCORSConfig{AllowOrigins: []string{"https://example.com"}, MaxAgeSeconds: 600},
+		}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	urls := mocks.ByType(functionURLToken)
+	if len(urls) != 1 {
+		t.Fatalf("expected 1 function URL, got %d", len(urls))
+	}
+	url := urls[0]
+	if got := url.Inputs["authorizationType"].StringValue(); got != "NONE" {
+		t.Errorf("authorizationType = %s, want NONE", got)
+	}
+	cors := url.Inputs["cors"].ObjectValue()
+	if got := cors["allowOrigins"].ArrayValue()[0].StringValue(); got != "https://example.com" {
+		t.Errorf("cors allowOrigins = %v, want example.com", got)
+	}
+
+	permissions := mocks.ByType(permissionToken)
+	if len(permissions) != 1 {
+		t.Fatalf("expected 1 public-access permission, got %d", len(permissions))
+	}
+	perm := permissions[0]
+	if got := perm.Inputs["action"].StringValue(); got != "lambda:InvokeFunctionUrl" {
+		t.Errorf("permission action = %s, want lambda:InvokeFunctionUrl", got)
+	}
+	if got := perm.Inputs["principal"].StringValue(); got != "*" {
+		t.Errorf("permission principal = %s, want *", got)
+	}
+	if got := perm.Inputs["functionUrlAuthType"].StringValue(); got != "NONE" {
+		t.Errorf("functionUrlAuthType = %s, want NONE", got)
+	}
+}
+
+func TestIAMFunctionURLSkipsPublicPermission(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.FunctionURL = &FunctionURLConfig{AuthType: "AWS_IAM"}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	url := mocks.ByType(functionURLToken)[0]
+	if got := url.Inputs["authorizationType"].StringValue(); got != "AWS_IAM" {
+		t.Errorf("authorizationType = %s, want AWS_IAM", got)
+	}
+	if permissions := mocks.ByType(permissionToken); len(permissions) != 0 {
+		t.Errorf("expected no permission for AWS_IAM auth, got %d", len(permissions))
+	}
+}
+
+func TestFunctionURLRejectsCredentialedWildcard(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.FunctionURL = &FunctionURLConfig{
+			AuthType: "NONE",
+			CORS:     &FunctionURLCORSConfig{AllowOrigins: []string{"*"}, AllowCredentials: true},
+		}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected wildcard origin with credentials to be rejected")
+	}
+}
@@ -0,0 +1,71 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestImagePackagedFunction(t *testing.T) {
+	imageUri := "123456789012.dkr.ecr.us-east-1.amazonaws.com/handler:latest"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewLambdaFunction(ctx, "handler", &LambdaConfig{
+			Environment: "dev",
+			PackageType: "Image",
+			ImageUri:    imageUri,
+			ImageConfig: &ImageConfig{
+				Command:          []string{"app.handler"},
+				WorkingDirectory: "/srv",
+			},
+		})
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["packageType"].StringValue(); got != "Image" {
+		t.Errorf("packageType = %s, want Image", got)
+	}
+	if got := function.Inputs["imageUri"].StringValue(); got != imageUri {
+		t.Errorf("imageUri = %s, want %s", got, imageUri)
+	}
+	if !function.Inputs["runtime"].IsNull() {
+		t.Error("runtime must not be set for image-packaged functions")
+	}
+	if !function.Inputs["handler"].IsNull() {
+		t.Error("handler must not be set for image-packaged functions")
+	}
+	imageConfig := function.Inputs["imageConfig"].ObjectValue()
+	if got := imageConfig["commands"].ArrayValue()[0].StringValue(); got != "app.handler" {
+		t.Errorf("imageConfig command = %s, want app.handler", got)
+	}
+	if got := imageConfig["workingDirectory"].StringValue(); got != "/srv" {
+		t.Errorf("imageConfig workingDirectory = %s, want /srv", got)
+	}
+}
+
+func TestPackagingRequiresExactlyOneSource(t *testing.T) {
+	cases := []struct {
+		name   string
+		config *LambdaConfig
+	}{
+		{"zip without code", &LambdaConfig{Environment: "dev"}},
+		{"image without uri", &LambdaConfig{Environment: "dev", PackageType: "Image"}},
+		{"both code and image", &LambdaConfig{
+			Environment: "dev",
+			PackageType: "Image",
+			ImageUri:    "123456789012.dkr.ecr.us-east-1.amazonaws.com/handler:latest",
+			Code:        pulumi.NewFileArchive("testdata/handler.zip"),
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				_, err := NewLambdaFunction(ctx, "handler", tc.config)
+				return err
+			}, pulumi.WithMocks("project", "stack", &testMocks{}))
+			if err == nil {
+				t.Fatal("expected config to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package lambda
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestKmsKeyPropagatesWithDecryptGrant(t *testing.T) {
+	keyArn := "arn:aws:kms:us-east-1:123456789012:key/abc-123"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.KmsKeyArn = keyArn
+		config.EnvironmentVariables = map[string]string{"SECRET": "value"}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["kmsKeyArn"].StringValue(); got != keyArn {
+		t.Errorf("kmsKeyArn = %s, want %s", got, keyArn)
+	}
+
+	found := false
+	for _, p := range mocks.ByType(rolePolicyToken) {
+		doc := p.Inputs["policy"].StringValue()
+		if strings.Contains(doc, "kms:Decrypt") && strings.Contains(doc, keyArn) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("no role policy grants kms:Decrypt on the key")
+	}
+}
+
+func TestKmsKeyRejectsMalformedArn(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.KmsKeyArn = "alias/my-key"
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected malformed KMS ARN to be rejected")
+	}
+}
@@ -0,0 +1,1179 @@
+// Package lambda provides a reusable Pulumi component for AWS Lambda
+// functions with sensible IAM, logging and alerting defaults.
+package lambda
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/cloudwatch"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/iam"
+	awslambda "github.com/pulumi/pulumi-aws/sdk/v6/go/aws/lambda"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sqs"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/profiles"
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// lambdaAssumeRolePolicy is the trust policy allowing Lambda to assume
+// the execution role.
+const lambdaAssumeRolePolicy = `{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Action": "sts:AssumeRole",
+		"Effect": "Allow",
+		"Principal": {"Service": "lambda.amazonaws.com"}
+	}]
+}`
+
+// VPCConfig places the function inside a VPC.
+type VPCConfig struct {
+	// SubnetIds lists the subnets the function may attach to.
+	SubnetIds []string
+	// SecurityGroupIds lists the security groups for the attachment.
+	SecurityGroupIds []string
+}
+
+// DeadLetterConfig routes asynchronous invocations that exhaust their
+// retries to an SNS topic or SQS queue instead of dropping them.
+type DeadLetterConfig struct {
+	// TargetArn is an existing SNS topic or SQS queue ARN.
+	TargetArn string
+	// AutoCreateQueue provisions an SQS queue as the DLQ instead of
+	// using TargetArn.
+	AutoCreateQueue bool
+}
+
+// EventSourceConfig attaches the function to an SQS queue, Kinesis
+// stream or DynamoDB stream as a poll-based consumer.
+type EventSourceConfig struct {
+	// ARN is the event source ARN. The service in the ARN determines
+	// which managed read policy is attached to the role.
+	ARN string
+	// BatchSize is the number of records per invocation. Zero uses the
+	// service default.
+	BatchSize int
+	// BatchWindowSeconds is the maximum time to gather records before
+	// invoking.
+	BatchWindowSeconds int
+	// StartingPosition is required for stream sources, e.g. "LATEST"
+	// or "TRIM_HORIZON". Ignored for SQS.
+	StartingPosition string
+	// Enabled controls polling. Nil defaults to enabled.
+	Enabled *bool
+}
+
+// ImageConfig overrides the container settings baked into an
+// Image-packaged function.
+type ImageConfig struct {
+	// EntryPoint overrides the image's ENTRYPOINT.
+	EntryPoint []string
+	// Command overrides the image's CMD.
+	Command []string
+	// WorkingDirectory overrides the image's WORKDIR.
+	WorkingDirectory string
+}
+
+// FunctionURLCORSConfig configures cross-origin access to a function
+// URL.
+type FunctionURLCORSConfig struct {
+	// AllowOrigins lists permitted origins, e.g. "https://example.com".
+	AllowOrigins []string
+	// AllowMethods lists permitted HTTP methods.
+	AllowMethods []string
+	// AllowHeaders lists permitted request headers.
+	AllowHeaders []string
+	// ExposeHeaders lists response headers readable by browsers.
+	ExposeHeaders []string
+	// MaxAgeSeconds is how long browsers may cache preflight results.
+	MaxAgeSeconds int
+	// AllowCredentials permits cookies and authorization headers.
+	AllowCredentials bool
+}
+
+// FunctionURLConfig exposes the function over a dedicated HTTPS
+// endpoint without an API Gateway in front.
+type FunctionURLConfig struct {
+	// AuthType is "NONE" for a public endpoint or "AWS_IAM" to require
+	// SigV4-signed requests. Defaults to "AWS_IAM".
+	AuthType string
+	// CORS optionally configures cross-origin access.
+	CORS *FunctionURLCORSConfig
+	// InvokeMode is "BUFFERED" (default) or "RESPONSE_STREAM".
+	InvokeMode string
+}
+
+// LogSubscriptionFilterConfig forwards matching log events to a
+// destination such as OpenSearch, a Kinesis stream or another Lambda.
+type LogSubscriptionFilterConfig struct {
+	// DestinationArn receives the log events.
+	DestinationArn string
+	// FilterPattern selects events to forward. Empty forwards
+	// everything.
+	FilterPattern string
+	// RoleArn lets CloudWatch Logs deliver to the destination. Required
+	// for Kinesis and Firehose destinations.
+	RoleArn string
+}
+
+// AsyncConfig controls asynchronous invocation retries and routes the
+// outcome of async invocations to destinations.
+type AsyncConfig struct {
+	// MaximumRetryAttempts is the retry count for failed async
+	// invocations, 0-2. Nil keeps the service default of 2.
+	MaximumRetryAttempts *int
+	// MaximumEventAgeInSeconds discards events older than this,
+	// 60-21600. Nil keeps the service default of 21600.
+	MaximumEventAgeInSeconds *int
+	// OnSuccessDestinationArn receives successful invocation records
+	// (SQS, SNS, Lambda or EventBridge ARN).
+	OnSuccessDestinationArn string
+	// OnFailureDestinationArn receives failed invocation records after
+	// retries are exhausted.
+	OnFailureDestinationArn string
+}
+
+// AlertConfig creates CloudWatch alarms for the function.
+type AlertConfig struct {
+	// ErrorsThreshold alarms when the sum of errors over the period
+	// exceeds it. Zero disables the alarm.
+	ErrorsThreshold int
+	// DurationThresholdMs alarms when average duration exceeds it.
+	// Zero disables the alarm.
+	DurationThresholdMs int
+	// ThrottlesThreshold alarms when the sum of throttles over the
+	// period exceeds it. Zero disables the alarm.
+	ThrottlesThreshold int
+	// ErrorRateThreshold alarms when the percentage of invocations that
+	// error over the period exceeds it, computed with metric math.
+	// Must be between 0 and 100; zero disables the alarm.
+	ErrorRateThreshold float64
+	// PeriodSeconds is the evaluation period. Defaults to 300.
+	PeriodSeconds int
+	// NotificationARN receives alarm state changes (usually SNS).
+	NotificationARN string
+}
+
+// LambdaConfig configures a LambdaFunction component.
+type LambdaConfig struct {
+	// Environment tags every created resource (e.g. "dev", "prod").
+	Environment string
+	// Runtime is the Lambda runtime, e.g. "go1.x" or "python3.12".
+	Runtime string
+	// Handler is the function entry point.
+	Handler string
+	// Architecture is "x86_64" (default) or "arm64". Any layers
+	// attached to the function must be built for the same architecture.
+	Architecture string
+	// Code is the deployment package. Required for Zip packaging,
+	// mutually exclusive with ImageUri.
+	Code pulumi.ArchiveInput
+	// PackageType is "Zip" (default) or "Image".
+	PackageType string
+	// ImageUri is the ECR image to run. Required for Image packaging.
+	ImageUri string
+	// ImageConfig optionally overrides the image's entrypoint, command
+	// and working directory.
+	ImageConfig *ImageConfig
+	// CodeSigningConfigArn enforces signed deployment packages using an
+	// existing code signing config. Only valid with Zip packaging;
+	// mutually exclusive with SigningProfileArns.
+	CodeSigningConfigArn string
+	// SigningProfileArns creates a code signing config trusting the
+	// given signer profile version ARNs and enforcing it on deployment.
+	// Only valid with Zip packaging.
+	SigningProfileArns []string
+	// SnapStart enables Lambda SnapStart on published versions to cut
+	// cold start latency. Only supported for Java runtimes.
+	SnapStart bool
+	// MemoryMB is the memory allocation. Defaults to the environment
+	// profile, then 128.
+	MemoryMB int
+	// TimeoutSeconds is the invocation timeout. Defaults to the
+	// environment profile, then 3.
+	TimeoutSeconds int
+	// EphemeralStorageMB sizes /tmp between 512 and 10240 MB. Nil
+	// keeps the 512 MB default.
+	EphemeralStorageMB *int
+	// EnvironmentVariables are exposed to the function at runtime.
+	EnvironmentVariables map[string]string
+	// KmsKeyArn encrypts environment variables with a customer-managed
+	// key instead of the default Lambda service key.
+	KmsKeyArn string
+	// ReservedConcurrentExecutions caps concurrent executions for the
+	// function. Nil leaves the account's unreserved pool in effect.
+	ReservedConcurrentExecutions *int
+	// ProvisionedConcurrency keeps the given number of execution
+	// environments warm on the prod alias.
+	ProvisionedConcurrency *int
+	// EnableXRay turns on active tracing and attaches the X-Ray write
+	// policy to the role.
+	EnableXRay bool
+	// VPC optionally attaches the function to a VPC.
+	VPC *VPCConfig
+	// DeadLetter optionally configures a dead-letter target for failed
+	// asynchronous invocations.
+	DeadLetter *DeadLetterConfig
+	// EventSources attaches poll-based event sources to the function.
+	EventSources []EventSourceConfig
+	// FunctionURL optionally exposes the function over a dedicated
+	// HTTPS endpoint.
+	FunctionURL *FunctionURLConfig
+	// ManagedPolicyARNs lists additional managed policies to attach to
+	// the execution role.
+	ManagedPolicyARNs []string
+	// InlinePolicies maps policy names to JSON policy documents added
+	// to the execution role.
+	InlinePolicies map[string]string
+	// PermissionsBoundary is an optional boundary policy ARN for the
+	// execution role.
+	PermissionsBoundary string
+	// LogGroupName overrides the default /aws/lambda/<name> log group
+	// name.
+	LogGroupName string
+	// LogRetentionDays sets log group retention. Defaults to the
+	// environment profile, then 30.
+	LogRetentionDays int
+	// LogSubscriptionFilter optionally forwards log events to a
+	// processing destination.
+	LogSubscriptionFilter *LogSubscriptionFilterConfig
+	// RoutingConfig shifts a fraction of the prod alias traffic to
+	// additional published versions, keyed by version. The weights must
+	// sum to less than 1.0; the remainder stays on the latest version.
+	RoutingConfig map[string]float64
+	// CreateAlias controls whether the alias tracking the published
+	// version is created. Nil defaults to true. Disable it for simple
+	// functions or when aliases are managed out of band; alias-bound
+	// features (RoutingConfig, ProvisionedConcurrency, Async) are then
+	// rejected.
+	CreateAlias *bool
+	// AliasName overrides the alias name. Defaults to "prod".
+	AliasName string
+	// Async optionally tunes asynchronous invocation behavior.
+	Async *AsyncConfig
+	// Alerts optionally creates CloudWatch alarms for the function.
+	Alerts *AlertConfig
+	// CreateDashboard creates a CloudWatch dashboard with the
+	// function's key metrics.
+	CreateDashboard bool
+	// ImportID adopts an existing function instead of creating a new
+	// one, via pulumi.Import. Child resources (role, log group, alias) are
+	// still created and must not already exist.
+	ImportID string
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// LambdaFunction is a Lambda function with an execution role, log
+// group, published version, prod alias and optional alarms.
+type LambdaFunction struct {
+	pulumi.ResourceState
+
+	// Function is the underlying Lambda function.
+	Function *awslambda.Function
+	// Role is the execution role.
+	Role *iam.Role
+	// Alias is the prod alias pointing at the published version.
+	Alias *awslambda.Alias
+	// Version is the published, immutable version the alias references.
+	Version pulumi.StringOutput
+	// FunctionArn is the unqualified function ARN.
+	FunctionArn pulumi.StringOutput
+	// RoleArn is the execution role ARN.
+	RoleArn pulumi.StringOutput
+	// LogGroupName is the function's log group name.
+	LogGroupName pulumi.StringOutput
+	// DeadLetterQueueArn is the ARN of the auto-created DLQ. Unset
+	// unless DeadLetter.AutoCreateQueue is enabled.
+	DeadLetterQueueArn pulumi.StringOutput
+	// EventSourceMappingIds holds the IDs of the created event source
+	// mappings, in config order.
+	EventSourceMappingIds []pulumi.StringOutput
+	// FunctionURL is the HTTPS endpoint URL. Unset unless a
+	// FunctionURLConfig was given.
+	FunctionURL pulumi.StringOutput
+	// DashboardName is the metrics dashboard name. Unset unless
+	// CreateDashboard is enabled.
+	DashboardName pulumi.StringOutput
+}
+
+// validRuntimes are the Lambda runtimes accepted for Zip packages.
+var validRuntimes = map[string]bool{
+	"nodejs16.x":      true,
+	"nodejs18.x":      true,
+	"nodejs20.x":      true,
+	"python3.9":       true,
+	"python3.10":      true,
+	"python3.11":      true,
+	"python3.12":      true,
+	"go1.x":           true,
+	"provided.al2":    true,
+	"provided.al2023": true,
+	"java11":          true,
+	"java17":          true,
+	"java21":          true,
+	"dotnet6":         true,
+	"dotnet8":         true,
+	"ruby3.2":         true,
+	"ruby3.3":         true,
+}
+
+// Validate checks required fields and enum values before any resources
+// are created, returning every problem found as one joined error.
+func (c *LambdaConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	switch c.PackageType {
+	case "", "Zip":
+		if c.Runtime == "" {
+			errs = append(errs, fmt.Errorf("Runtime is required for Zip packages"))
+		} else if !validRuntimes[c.Runtime] {
+			errs = append(errs, fmt.Errorf("invalid Runtime %q", c.Runtime))
+		}
+	}
+	if c.Alerts != nil && (c.Alerts.ErrorRateThreshold < 0 || c.Alerts.ErrorRateThreshold > 100) {
+		errs = append(errs, fmt.Errorf("invalid ErrorRateThreshold %v: must be between 0 and 100", c.Alerts.ErrorRateThreshold))
+	}
+	if c.CreateAlias != nil && !*c.CreateAlias {
+		if c.AliasName != "" {
+			errs = append(errs, fmt.Errorf("AliasName has no effect when CreateAlias is disabled"))
+		}
+		if len(c.RoutingConfig) > 0 {
+			errs = append(errs, fmt.Errorf("RoutingConfig shifts alias traffic and requires CreateAlias"))
+		}
+		if c.ProvisionedConcurrency != nil {
+			errs = append(errs, fmt.Errorf("ProvisionedConcurrency targets the alias and requires CreateAlias"))
+		}
+		if c.Async != nil {
+			errs = append(errs, fmt.Errorf("Async targets the alias and requires CreateAlias"))
+		}
+	}
+	if c.SnapStart && !strings.HasPrefix(c.Runtime, "java") {
+		errs = append(errs, fmt.Errorf("SnapStart is only supported for Java runtimes, got %q", c.Runtime))
+	}
+	if c.CodeSigningConfigArn != "" || len(c.SigningProfileArns) > 0 {
+		if c.CodeSigningConfigArn != "" && len(c.SigningProfileArns) > 0 {
+			errs = append(errs, fmt.Errorf("CodeSigningConfigArn and SigningProfileArns are mutually exclusive"))
+		}
+		if c.PackageType == "Image" {
+			errs = append(errs, fmt.Errorf("code signing requires Zip packaging; it is not supported for container images"))
+		}
+		if c.CodeSigningConfigArn != "" && !strings.HasPrefix(c.CodeSigningConfigArn, "arn:") {
+			errs = append(errs, fmt.Errorf("invalid CodeSigningConfigArn %q: expected an ARN", c.CodeSigningConfigArn))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewLambdaFunction creates a Lambda function from the given config,
+// publishing a version on every deploy and pointing the prod alias at
+// it so rollouts reference immutable code.
+func NewLambdaFunction(ctx *pulumi.Context, name string, config *LambdaConfig, opts ...pulumi.ResourceOption) (*LambdaFunction, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &LambdaFunction{}
+	err := ctx.RegisterComponentResource("denecloud:aws:LambdaFunction", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	resourceTags := tags.MergeTags(config.Environment, config.Tags)
+
+	roleArgs := &iam.RoleArgs{
+		Name:             pulumi.Sprintf("%s-role", name),
+		AssumeRolePolicy: pulumi.String(lambdaAssumeRolePolicy),
+		Tags:             resourceTags,
+	}
+	if config.PermissionsBoundary != "" {
+		roleArgs.PermissionsBoundary = pulumi.String(config.PermissionsBoundary)
+	}
+	role, err := iam.NewRole(ctx, name, roleArgs, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Role = role
+	comp.RoleArn = role.Arn
+
+	_, err = iam.NewRolePolicyAttachment(ctx, name+"-basic-execution", &iam.RolePolicyAttachmentArgs{
+		Role:      role.Name,
+		PolicyArn: pulumi.String("arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"),
+	}, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	if config.EnableXRay {
+		_, err = iam.NewRolePolicyAttachment(ctx, name+"-xray-write", &iam.RolePolicyAttachmentArgs{
+			Role:      role.Name,
+			PolicyArn: pulumi.String("arn:aws:iam::aws:policy/AWSXRayDaemonWriteAccess"),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.VPC != nil {
+		_, err = iam.NewRolePolicyAttachment(ctx, name+"-vpc-access", &iam.RolePolicyAttachmentArgs{
+			Role:      role.Name,
+			PolicyArn: pulumi.String("arn:aws:iam::aws:policy/service-role/AWSLambdaVPCAccessExecutionRole"),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, policyArn := range config.ManagedPolicyARNs {
+		_, err = iam.NewRolePolicyAttachment(ctx, fmt.Sprintf("%s-managed-%d", name, i), &iam.RolePolicyAttachmentArgs{
+			Role:      role.Name,
+			PolicyArn: pulumi.String(policyArn),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, policyName := range sortedKeys(config.InlinePolicies) {
+		_, err = iam.NewRolePolicy(ctx, fmt.Sprintf("%s-%s", name, policyName), &iam.RolePolicyArgs{
+			Name:   pulumi.String(policyName),
+			Role:   role.Name,
+			Policy: pulumi.String(config.InlinePolicies[policyName]),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Zero-valued sizing fields fall back to the environment profile,
+	// if one is registered, before the package defaults.
+	profile := profiles.Lookup(config.Environment)
+
+	retention := config.LogRetentionDays
+	if retention == 0 {
+		retention = profile.LogRetentionDays
+	}
+	if retention == 0 {
+		retention = 30
+	}
+	logGroupName := config.LogGroupName
+	if logGroupName == "" {
+		logGroupName = "/aws/lambda/" + name
+	}
+	logGroup, err := cloudwatch.NewLogGroup(ctx, name, &cloudwatch.LogGroupArgs{
+		Name:            pulumi.String(logGroupName),
+		RetentionInDays: pulumi.Int(retention),
+		Tags:            resourceTags,
+	}, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.LogGroupName = logGroup.Name
+
+	if filter := config.LogSubscriptionFilter; filter != nil {
+		if filter.DestinationArn == "" {
+			return nil, fmt.Errorf("LogSubscriptionFilter requires a DestinationArn")
+		}
+		filterArgs := &cloudwatch.LogSubscriptionFilterArgs{
+			LogGroup:       logGroup.Name,
+			DestinationArn: pulumi.String(filter.DestinationArn),
+			FilterPattern:  pulumi.String(filter.FilterPattern),
+		}
+		if filter.RoleArn != "" {
+			filterArgs.RoleArn = pulumi.String(filter.RoleArn)
+		}
+		_, err = cloudwatch.NewLogSubscriptionFilter(ctx, name+"-log-subscription", filterArgs, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	memory := config.MemoryMB
+	if memory == 0 {
+		memory = profile.LambdaMemoryMB
+	}
+	if memory == 0 {
+		memory = 128
+	}
+	timeout := config.TimeoutSeconds
+	if timeout == 0 {
+		timeout = profile.LambdaTimeoutSeconds
+	}
+	if timeout == 0 {
+		timeout = 3
+	}
+	functionArgs := &awslambda.FunctionArgs{
+		Name:       pulumi.String(name),
+		Role:       role.Arn,
+		MemorySize: pulumi.Int(memory),
+		Timeout:    pulumi.Int(timeout),
+		// Publish a version on every code change so the alias can
+		// reference immutable code instead of $LATEST.
+		Publish: pulumi.Bool(true),
+		Tags:    resourceTags,
+	}
+	switch config.PackageType {
+	case "", "Zip":
+		if config.Code == nil {
+			return nil, fmt.Errorf("Zip-packaged functions require Code")
+		}
+		if config.ImageUri != "" {
+			return nil, fmt.Errorf("ImageUri requires PackageType Image")
+		}
+		functionArgs.Runtime = pulumi.String(config.Runtime)
+		functionArgs.Handler = pulumi.String(config.Handler)
+		functionArgs.Code = config.Code
+	case "Image":
+		if config.ImageUri == "" {
+			return nil, fmt.Errorf("Image-packaged functions require an ImageUri")
+		}
+		if config.Code != nil {
+			return nil, fmt.Errorf("Code and ImageUri are mutually exclusive")
+		}
+		// Runtime and Handler are baked into the image and must not be
+		// set on the function.
+		functionArgs.PackageType = pulumi.String("Image")
+		functionArgs.ImageUri = pulumi.String(config.ImageUri)
+		if image := config.ImageConfig; image != nil {
+			imageArgs := &awslambda.FunctionImageConfigArgs{
+				EntryPoints: pulumi.ToStringArray(image.EntryPoint),
+				Commands:    pulumi.ToStringArray(image.Command),
+			}
+			if image.WorkingDirectory != "" {
+				imageArgs.WorkingDirectory = pulumi.String(image.WorkingDirectory)
+			}
+			functionArgs.ImageConfig = imageArgs
+		}
+	default:
+		return nil, fmt.Errorf("invalid PackageType %q: must be Zip or Image", config.PackageType)
+	}
+	if config.EphemeralStorageMB != nil {
+		size := *config.EphemeralStorageMB
+		if size < 512 || size > 10240 {
+			return nil, fmt.Errorf("EphemeralStorageMB must be between 512 and 10240, got %d", size)
+		}
+		functionArgs.EphemeralStorage = &awslambda.FunctionEphemeralStorageArgs{
+			Size: pulumi.Int(size),
+		}
+	}
+	if config.SnapStart {
+		// Snapshots are taken of published versions, which the
+		// component publishes anyway for its alias.
+		functionArgs.SnapStart = &awslambda.FunctionSnapStartArgs{
+			ApplyOn: pulumi.String("PublishedVersions"),
+		}
+	}
+	if config.CodeSigningConfigArn != "" {
+		functionArgs.CodeSigningConfigArn = pulumi.String(config.CodeSigningConfigArn)
+	}
+	if len(config.SigningProfileArns) > 0 {
+		signing, err := awslambda.NewCodeSigningConfig(ctx, name+"-signing", &awslambda.CodeSigningConfigArgs{
+			AllowedPublishers: &awslambda.CodeSigningConfigAllowedPublishersArgs{
+				SigningProfileVersionArns: pulumi.ToStringArray(config.SigningProfileArns),
+			},
+			Policies: &awslambda.CodeSigningConfigPoliciesArgs{
+				UntrustedArtifactOnDeployment: pulumi.String("Enforce"),
+			},
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		functionArgs.CodeSigningConfigArn = signing.Arn
+	}
+	if config.KmsKeyArn != "" {
+		if !strings.HasPrefix(config.KmsKeyArn, "arn:aws:kms:") {
+			return nil, fmt.Errorf("invalid KmsKeyArn %q: expected a KMS key ARN", config.KmsKeyArn)
+		}
+		functionArgs.KmsKeyArn = pulumi.String(config.KmsKeyArn)
+		_, err = iam.NewRolePolicy(ctx, name+"-kms-decrypt", &iam.RolePolicyArgs{
+			Role: role.Name,
+			Policy: pulumi.Sprintf(`{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Action": "kms:Decrypt",
+		"Resource": "%s"
+	}]
+}`, config.KmsKeyArn),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch config.Architecture {
+	case "":
+		// Leave unset; the provider defaults to x86_64.
+	case "x86_64", "arm64":
+		functionArgs.Architectures = pulumi.StringArray{pulumi.String(config.Architecture)}
+	default:
+		return nil, fmt.Errorf("invalid Architecture %q: must be x86_64 or arm64", config.Architecture)
+	}
+	if config.ReservedConcurrentExecutions != nil {
+		if *config.ReservedConcurrentExecutions < 0 {
+			return nil, fmt.Errorf("ReservedConcurrentExecutions must be non-negative, got %d", *config.ReservedConcurrentExecutions)
+		}
+		functionArgs.ReservedConcurrentExecutions = pulumi.Int(*config.ReservedConcurrentExecutions)
+	}
+	// Keep the tracing mode aligned with the X-Ray policy attachment.
+	tracingMode := "PassThrough"
+	if config.EnableXRay {
+		tracingMode = "Active"
+	}
+	functionArgs.TracingConfig = &awslambda.FunctionTracingConfigArgs{
+		Mode: pulumi.String(tracingMode),
+	}
+	if len(config.EnvironmentVariables) > 0 {
+		variables := pulumi.StringMap{}
+		for k, v := range config.EnvironmentVariables {
+			variables[k] = pulumi.String(v)
+		}
+		functionArgs.Environment = &awslambda.FunctionEnvironmentArgs{
+			Variables: variables,
+		}
+	}
+	if config.VPC != nil {
+		functionArgs.VpcConfig = &awslambda.FunctionVpcConfigArgs{
+			SubnetIds:        pulumi.ToStringArray(config.VPC.SubnetIds),
+			SecurityGroupIds: pulumi.ToStringArray(config.VPC.SecurityGroupIds),
+		}
+	}
+	if config.DeadLetter != nil {
+		target := pulumi.StringInput(pulumi.String(config.DeadLetter.TargetArn))
+		action := "sqs:SendMessage"
+		if config.DeadLetter.AutoCreateQueue {
+			queue, err := sqs.NewQueue(ctx, name+"-dlq", &sqs.QueueArgs{
+				Name: pulumi.Sprintf("%s-dlq", name),
+				Tags: resourceTags,
+			}, parentOpts...)
+			if err != nil {
+				return nil, err
+			}
+			target = queue.Arn
+			comp.DeadLetterQueueArn = queue.Arn
+		} else {
+			if config.DeadLetter.TargetArn == "" {
+				return nil, fmt.Errorf("DeadLetter requires a TargetArn or AutoCreateQueue")
+			}
+			if strings.HasPrefix(config.DeadLetter.TargetArn, "arn:aws:sns") {
+				action = "sns:Publish"
+			}
+		}
+		_, err = iam.NewRolePolicy(ctx, name+"-dlq-publish", &iam.RolePolicyArgs{
+			Role: role.Name,
+			Policy: pulumi.Sprintf(`{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Action": "%s",
+		"Resource": "%s"
+	}]
+}`, action, target),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		functionArgs.DeadLetterConfig = &awslambda.FunctionDeadLetterConfigArgs{
+			TargetArn: target,
+		}
+	}
+	functionOpts := append(parentOpts, pulumi.DependsOn([]pulumi.Resource{logGroup}))
+	if config.ImportID != "" {
+		functionOpts = append([]pulumi.ResourceOption{pulumi.Import(pulumi.ID(config.ImportID))}, functionOpts...)
+	}
+	function, err := awslambda.NewFunction(ctx, name, functionArgs, functionOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Function = function
+	comp.FunctionArn = function.Arn
+	comp.Version = function.Version
+
+	if config.CreateAlias == nil || *config.CreateAlias {
+		aliasName := config.AliasName
+		if aliasName == "" {
+			aliasName = "prod"
+		}
+		aliasArgs := &awslambda.AliasArgs{
+			Name:            pulumi.String(aliasName),
+			FunctionName:    function.Name,
+			FunctionVersion: function.Version,
+		}
+		if len(config.RoutingConfig) > 0 {
+			weightSum := 0.0
+			weights := pulumi.Float64Map{}
+			for version, weight := range config.RoutingConfig {
+				if version == "" || version == "$LATEST" {
+					return nil, fmt.Errorf("RoutingConfig requires published version numbers, got %q", version)
+				}
+				if weight <= 0 || weight >= 1 {
+					return nil, fmt.Errorf("RoutingConfig weight for version %s must be between 0 and 1, got %v", version, weight)
+				}
+				weightSum += weight
+				weights[version] = pulumi.Float64(weight)
+			}
+			if weightSum >= 1 {
+				return nil, fmt.Errorf("RoutingConfig weights must sum to less than 1.0, got %v", weightSum)
+			}
+			aliasArgs.RoutingConfig = &awslambda.AliasRoutingConfigArgs{
+				AdditionalVersionWeights: weights,
+			}
+		}
+		alias, err := awslambda.NewAlias(ctx, name+"-"+aliasName, aliasArgs, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		comp.Alias = alias
+	}
+
+	// Validate rejects the alias-bound configs below when CreateAlias
+	// is disabled, so comp.Alias is always set when they run.
+	if config.Async != nil {
+		if err := comp.buildAsyncConfig(ctx, name, config.Async, function, comp.Alias, role, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.ProvisionedConcurrency != nil {
+		// Provisioned concurrency can only target a version or alias,
+		// never $LATEST, which is why the alias tracks the published
+		// version above.
+		_, err = awslambda.NewProvisionedConcurrencyConfig(ctx, name+"-provisioned", &awslambda.ProvisionedConcurrencyConfigArgs{
+			FunctionName:                    function.Name,
+			Qualifier:                       comp.Alias.Name,
+			ProvisionedConcurrentExecutions: pulumi.Int(*config.ProvisionedConcurrency),
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := comp.buildEventSources(ctx, name, config.EventSources, function, role, parentOpts); err != nil {
+		return nil, err
+	}
+
+	if config.FunctionURL != nil {
+		if err := comp.buildFunctionURL(ctx, name, config.FunctionURL, function, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Alerts != nil {
+		if err := comp.buildAlarms(ctx, name, config.Alerts, function, resourceTags, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.CreateDashboard {
+		if err := comp.buildDashboard(ctx, name, function, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	outputs := pulumi.Map{
+		"functionArn":  comp.FunctionArn,
+		"roleArn":      comp.RoleArn,
+		"version":      comp.Version,
+		"logGroupName": comp.LogGroupName,
+	}
+	for key, value := range map[string]pulumi.StringOutput{
+		"deadLetterQueueArn": comp.DeadLetterQueueArn,
+		"functionUrl":        comp.FunctionURL,
+		"dashboardName":      comp.DashboardName,
+	} {
+		if value != (pulumi.StringOutput{}) {
+			outputs[key] = value
+		}
+	}
+	if err := ctx.RegisterResourceOutputs(comp, outputs); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
+
+// buildDashboard creates a CloudWatch dashboard showing the function's
+// key operational metrics.
+func (comp *LambdaFunction) buildDashboard(ctx *pulumi.Context, name string, function *awslambda.Function, parentOpts []pulumi.ResourceOption) error {
+	// The body is templated on the function name output so the
+	// dashboard follows renames without a manual update.
+	body := function.Name.ApplyT(func(functionName string) (string, error) {
+		widget := func(index int, title string, metrics [][]any) map[string]any {
+			return map[string]any{
+				"type":   "metric",
+				"x":      (index % 3) * 8,
+				"y":      (index / 3) * 6,
+				"width":  8,
+				"height": 6,
+				"properties": map[string]any{
+					"title":   title,
+					"metrics": metrics,
+					"period":  300,
+					"view":    "timeSeries",
+				},
+			}
+		}
+		metric := func(name, stat string) []any {
+			return []any{"AWS/Lambda", name, "FunctionName", functionName, map[string]any{"stat": stat}}
+		}
+		widgets := []map[string]any{
+			widget(0, "Invocations", [][]any{metric("Invocations", "Sum")}),
+			widget(1, "Errors", [][]any{metric("Errors", "Sum")}),
+			widget(2, "Throttles", [][]any{metric("Throttles", "Sum")}),
+			widget(3, "Duration", [][]any{metric("Duration", "p50"), metric("Duration", "p99")}),
+			widget(4, "Concurrent executions", [][]any{metric("ConcurrentExecutions", "Maximum")}),
+		}
+		encoded, err := json.Marshal(map[string]any{"widgets": widgets})
+		return string(encoded), err
+	}).(pulumi.StringOutput)
+
+	dashboard, err := cloudwatch.NewDashboard(ctx, name, &cloudwatch.DashboardArgs{
+		DashboardName: pulumi.String(name),
+		DashboardBody: body,
+	}, parentOpts...)
+	if err != nil {
+		return err
+	}
+	comp.DashboardName = dashboard.DashboardName
+	return nil
+}
+
+// buildFunctionURL creates the function URL and, for public endpoints,
+// the permission that lets unauthenticated callers invoke it.
+func (comp *LambdaFunction) buildFunctionURL(ctx *pulumi.Context, name string, urlConfig *FunctionURLConfig, function *awslambda.Function, parentOpts []pulumi.ResourceOption) error {
+	authType := urlConfig.AuthType
+	if authType == "" {
+		authType = "AWS_IAM"
+	}
+	if authType != "NONE" && authType != "AWS_IAM" {
+		return fmt.Errorf("invalid function URL AuthType %q: must be NONE or AWS_IAM", authType)
+	}
+
+	urlArgs := &awslambda.FunctionUrlArgs{
+		FunctionName:      function.Name,
+		AuthorizationType: pulumi.String(authType),
+	}
+	if urlConfig.InvokeMode != "" {
+		urlArgs.InvokeMode = pulumi.String(urlConfig.InvokeMode)
+	}
+	if cors := urlConfig.CORS; cors != nil {
+		for _, origin := range cors.AllowOrigins {
+			if origin == "*" && cors.AllowCredentials {
+				return fmt.Errorf("function URL CORS cannot combine AllowCredentials with a wildcard origin")
+			}
+		}
+		corsArgs := &awslambda.FunctionUrlCorsArgs{
+			AllowOrigins:     pulumi.ToStringArray(cors.AllowOrigins),
+			AllowMethods:     pulumi.ToStringArray(cors.AllowMethods),
+			AllowHeaders:     pulumi.ToStringArray(cors.AllowHeaders),
+			ExposeHeaders:    pulumi.ToStringArray(cors.ExposeHeaders),
+			AllowCredentials: pulumi.Bool(cors.AllowCredentials),
+		}
+		if cors.MaxAgeSeconds > 0 {
+			corsArgs.MaxAge = pulumi.Int(cors.MaxAgeSeconds)
+		}
+		urlArgs.Cors = corsArgs
+	}
+	url, err := awslambda.NewFunctionUrl(ctx, name+"-url", urlArgs, parentOpts...)
+	if err != nil {
+		return err
+	}
+	comp.FunctionURL = url.FunctionUrl
+
+	if authType == "NONE" {
+		// Public URLs additionally need a resource policy allowing
+		// anyone to call InvokeFunctionUrl.
+		_, err = awslambda.NewPermission(ctx, name+"-url-public", &awslambda.PermissionArgs{
+			Action:              pulumi.String("lambda:InvokeFunctionUrl"),
+			Function:            function.Name,
+			Principal:           pulumi.String("*"),
+			FunctionUrlAuthType: pulumi.String("NONE"),
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// destinationAction maps an async destination ARN to the action the
+// execution role needs to deliver invocation records to it.
+func destinationAction(arn string) (string, error) {
+	switch {
+	case strings.HasPrefix(arn, "arn:aws:sqs:"):
+		return "sqs:SendMessage", nil
+	case strings.HasPrefix(arn, "arn:aws:sns:"):
+		return "sns:Publish", nil
+	case strings.HasPrefix(arn, "arn:aws:lambda:"):
+		return "lambda:InvokeFunction", nil
+	case strings.HasPrefix(arn, "arn:aws:events:"):
+		return "events:PutEvents", nil
+	}
+	return "", fmt.Errorf("unsupported async destination ARN %q: expected an SQS, SNS, Lambda or EventBridge ARN", arn)
+}
+
+// buildAsyncConfig applies retry and destination settings to the prod
+// alias and grants the role access to the destinations.
+func (comp *LambdaFunction) buildAsyncConfig(ctx *pulumi.Context, name string, async *AsyncConfig, function *awslambda.Function, alias *awslambda.Alias, role *iam.Role, parentOpts []pulumi.ResourceOption) error {
+	invokeArgs := &awslambda.FunctionEventInvokeConfigArgs{
+		FunctionName: function.Name,
+		Qualifier:    alias.Name,
+	}
+	if async.MaximumRetryAttempts != nil {
+		if *async.MaximumRetryAttempts < 0 || *async.MaximumRetryAttempts > 2 {
+			return fmt.Errorf("MaximumRetryAttempts must be between 0 and 2, got %d", *async.MaximumRetryAttempts)
+		}
+		invokeArgs.MaximumRetryAttempts = pulumi.Int(*async.MaximumRetryAttempts)
+	}
+	if async.MaximumEventAgeInSeconds != nil {
+		if *async.MaximumEventAgeInSeconds < 60 || *async.MaximumEventAgeInSeconds > 21600 {
+			return fmt.Errorf("MaximumEventAgeInSeconds must be between 60 and 21600, got %d", *async.MaximumEventAgeInSeconds)
+		}
+		invokeArgs.MaximumEventAgeInSeconds = pulumi.Int(*async.MaximumEventAgeInSeconds)
+	}
+
+	destinations := &awslambda.FunctionEventInvokeConfigDestinationConfigArgs{}
+	hasDestination := false
+	for _, destination := range []struct {
+		arn    string
+		suffix string
+	}{
+		{async.OnSuccessDestinationArn, "on-success"},
+		{async.OnFailureDestinationArn, "on-failure"},
+	} {
+		if destination.arn == "" {
+			continue
+		}
+		hasDestination = true
+		action, err := destinationAction(destination.arn)
+		if err != nil {
+			return err
+		}
+		_, err = iam.NewRolePolicy(ctx, fmt.Sprintf("%s-%s-deliver", name, destination.suffix), &iam.RolePolicyArgs{
+			Role: role.Name,
+			Policy: pulumi.Sprintf(`{
+	"Version": "2012-10-17",
+	"Statement": [{
+		"Effect": "Allow",
+		"Action": "%s",
+		"Resource": "%s"
+	}]
+}`, action, destination.arn),
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	if async.OnSuccessDestinationArn != "" {
+		destinations.OnSuccess = &awslambda.FunctionEventInvokeConfigDestinationConfigOnSuccessArgs{
+			Destination: pulumi.String(async.OnSuccessDestinationArn),
+		}
+	}
+	if async.OnFailureDestinationArn != "" {
+		destinations.OnFailure = &awslambda.FunctionEventInvokeConfigDestinationConfigOnFailureArgs{
+			Destination: pulumi.String(async.OnFailureDestinationArn),
+		}
+	}
+	if hasDestination {
+		invokeArgs.DestinationConfig = destinations
+	}
+
+	_, err := awslambda.NewFunctionEventInvokeConfig(ctx, name+"-async", invokeArgs, parentOpts...)
+	return err
+}
+
+// sortedKeys returns the map's keys in sorted order so resources are
+// created deterministically.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// eventSourceExecutionPolicy maps the service in an event source ARN to
+// the managed policy granting the Lambda poller read access.
+func eventSourceExecutionPolicy(arn string) (string, error) {
+	switch {
+	case strings.HasPrefix(arn, "arn:aws:sqs:"):
+		return "arn:aws:iam::aws:policy/service-role/AWSLambdaSQSQueueExecutionRole", nil
+	case strings.HasPrefix(arn, "arn:aws:kinesis:"):
+		return "arn:aws:iam::aws:policy/service-role/AWSLambdaKinesisExecutionRole", nil
+	case strings.HasPrefix(arn, "arn:aws:dynamodb:"):
+		return "arn:aws:iam::aws:policy/service-role/AWSLambdaDynamoDBExecutionRole", nil
+	}
+	return "", fmt.Errorf("unsupported event source ARN %q: expected an SQS, Kinesis or DynamoDB ARN", arn)
+}
+
+// buildEventSources creates an event source mapping per config entry
+// along with the managed policy the poller needs to read the source.
+func (comp *LambdaFunction) buildEventSources(ctx *pulumi.Context, name string, sources []EventSourceConfig, function *awslambda.Function, role *iam.Role, parentOpts []pulumi.ResourceOption) error {
+	attachedPolicies := map[string]bool{}
+	for i, source := range sources {
+		policyArn, err := eventSourceExecutionPolicy(source.ARN)
+		if err != nil {
+			return err
+		}
+		if !attachedPolicies[policyArn] {
+			attachedPolicies[policyArn] = true
+			_, err = iam.NewRolePolicyAttachment(ctx, fmt.Sprintf("%s-source-%d-read", name, i), &iam.RolePolicyAttachmentArgs{
+				Role:      role.Name,
+				PolicyArn: pulumi.String(policyArn),
+			}, parentOpts...)
+			if err != nil {
+				return err
+			}
+		}
+
+		mappingArgs := &awslambda.EventSourceMappingArgs{
+			EventSourceArn: pulumi.String(source.ARN),
+			FunctionName:   function.Arn,
+		}
+		if source.BatchSize > 0 {
+			mappingArgs.BatchSize = pulumi.Int(source.BatchSize)
+		}
+		if source.BatchWindowSeconds > 0 {
+			mappingArgs.MaximumBatchingWindowInSeconds = pulumi.Int(source.BatchWindowSeconds)
+		}
+		if source.StartingPosition != "" {
+			mappingArgs.StartingPosition = pulumi.String(source.StartingPosition)
+		}
+		if source.Enabled != nil {
+			mappingArgs.Enabled = pulumi.Bool(*source.Enabled)
+		}
+		mapping, err := awslambda.NewEventSourceMapping(ctx, fmt.Sprintf("%s-source-%d", name, i), mappingArgs, parentOpts...)
+		if err != nil {
+			return err
+		}
+		comp.EventSourceMappingIds = append(comp.EventSourceMappingIds, mapping.ID().ToStringOutput())
+	}
+	return nil
+}
+
+// buildAlarms creates the CloudWatch alarms described by the alert
+// config. Alarms with a zero threshold are skipped.
+func (comp *LambdaFunction) buildAlarms(ctx *pulumi.Context, name string, alerts *AlertConfig, function *awslambda.Function, resourceTags pulumi.StringMap, parentOpts []pulumi.ResourceOption) error {
+	period := alerts.PeriodSeconds
+	if period == 0 {
+		period = 300
+	}
+	dimensions := pulumi.StringMap{"FunctionName": function.Name}
+	var actions pulumi.ArrayInput
+	if alerts.NotificationARN != "" {
+		actions = pulumi.Array{pulumi.String(alerts.NotificationARN)}
+	}
+
+	if alerts.ErrorsThreshold > 0 {
+		_, err := cloudwatch.NewMetricAlarm(ctx, name+"-errors", &cloudwatch.MetricAlarmArgs{
+			Name:               pulumi.Sprintf("%s-errors", name),
+			ComparisonOperator: pulumi.String("GreaterThanThreshold"),
+			EvaluationPeriods:  pulumi.Int(1),
+			MetricName:         pulumi.String("Errors"),
+			Namespace:          pulumi.String("AWS/Lambda"),
+			Period:             pulumi.Int(period),
+			Statistic:          pulumi.String("Sum"),
+			Threshold:          pulumi.Float64(float64(alerts.ErrorsThreshold)),
+			Dimensions:         dimensions,
+			AlarmActions:       actions,
+			Tags:               resourceTags,
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	if alerts.ThrottlesThreshold > 0 {
+		_, err := cloudwatch.NewMetricAlarm(ctx, name+"-throttles", &cloudwatch.MetricAlarmArgs{
+			Name:               pulumi.Sprintf("%s-throttles", name),
+			ComparisonOperator: pulumi.String("GreaterThanThreshold"),
+			EvaluationPeriods:  pulumi.Int(1),
+			MetricName:         pulumi.String("Throttles"),
+			Namespace:          pulumi.String("AWS/Lambda"),
+			Period:             pulumi.Int(period),
+			Statistic:          pulumi.String("Sum"),
+			Threshold:          pulumi.Float64(float64(alerts.ThrottlesThreshold)),
+			Dimensions:         dimensions,
+			AlarmActions:       actions,
+			Tags:               resourceTags,
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	if alerts.ErrorRateThreshold > 0 {
+		// Rate alarms use metric math so the threshold tracks traffic.
+		// Missing data (an idle function) is treated as healthy rather
+		// than letting the division alarm on no invocations.
+		_, err := cloudwatch.NewMetricAlarm(ctx, name+"-error-rate", &cloudwatch.MetricAlarmArgs{
+			Name:               pulumi.Sprintf("%s-error-rate", name),
+			ComparisonOperator: pulumi.String("GreaterThanThreshold"),
+			EvaluationPeriods:  pulumi.Int(1),
+			Threshold:          pulumi.Float64(alerts.ErrorRateThreshold),
+			TreatMissingData:   pulumi.String("notBreaching"),
+			MetricQueries: cloudwatch.MetricAlarmMetricQueryArray{
+				&cloudwatch.MetricAlarmMetricQueryArgs{
+					Id:         pulumi.String("rate"),
+					Expression: pulumi.String("errors / invocations * 100"),
+					Label:      pulumi.String("Error rate (%)"),
+					ReturnData: pulumi.Bool(true),
+				},
+				&cloudwatch.MetricAlarmMetricQueryArgs{
+					Id: pulumi.String("errors"),
+					Metric: &cloudwatch.MetricAlarmMetricQueryMetricArgs{
+						MetricName: pulumi.String("Errors"),
+						Namespace:  pulumi.String("AWS/Lambda"),
+						Period:     pulumi.Int(period),
+						Stat:       pulumi.String("Sum"),
+						Dimensions: dimensions,
+					},
+				},
+				&cloudwatch.MetricAlarmMetricQueryArgs{
+					Id: pulumi.String("invocations"),
+					Metric: &cloudwatch.MetricAlarmMetricQueryMetricArgs{
+						MetricName: pulumi.String("Invocations"),
+						Namespace:  pulumi.String("AWS/Lambda"),
+						Period:     pulumi.Int(period),
+						Stat:       pulumi.String("Sum"),
+						Dimensions: dimensions,
+					},
+				},
+			},
+			AlarmActions: actions,
+			Tags:         resourceTags,
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	if alerts.DurationThresholdMs > 0 {
+		_, err := cloudwatch.NewMetricAlarm(ctx, name+"-duration", &cloudwatch.MetricAlarmArgs{
+			Name:               pulumi.Sprintf("%s-duration", name),
+			ComparisonOperator: pulumi.String("GreaterThanThreshold"),
+			EvaluationPeriods:  pulumi.Int(1),
+			MetricName:         pulumi.String("Duration"),
+			Namespace:          pulumi.String("AWS/Lambda"),
+			Period:             pulumi.Int(period),
+			Statistic:          pulumi.String("Average"),
+			Threshold:          pulumi.Float64(float64(alerts.DurationThresholdMs)),
+			Dimensions:         dimensions,
+			AlarmActions:       actions,
+			Tags:               resourceTags,
+		}, parentOpts...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	functionToken = "aws:lambda/function:Function"
+	aliasToken    = "aws:lambda/alias:Alias"
+)
+
+func TestAliasReferencesPublishedVersion(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewLambdaFunction(ctx, "handler", testConfig())
+		return err
+	})
+
+	functions := mocks.ByType(functionToken)
+	if len(functions) != 1 {
+		t.Fatalf("expected 1 function, got %d", len(functions))
+	}
+	if !functions[0].Inputs["publish"].BoolValue() {
+		t.Error("function is not published")
+	}
+
+	aliases := mocks.ByType(aliasToken)
+	if len(aliases) != 1 {
+		t.Fatalf("expected 1 alias, got %d", len(aliases))
+	}
+	version := aliases[0].Inputs["functionVersion"]
+	if version.IsNull() {
+		t.Fatal("alias has no function version")
+	}
+	if got := version.StringValue(); got == "$LATEST" {
+		t.Error("alias still references $LATEST instead of the published version")
+	}
+}
+
+func TestTracingFollowsEnableXRay(t *testing.T) {
+	cases := []struct {
+		name       string
+		enableXRay bool
+		wantMode   string
+	}{
+		{"disabled", false, "PassThrough"},
+		{"enabled", true, "Active"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mocks := runTest(t, func(ctx *pulumi.Context) error {
+				config := testConfig()
+				config.EnableXRay = tc.enableXRay
+				_, err := NewLambdaFunction(ctx, "handler", config)
+				return err
+			})
+
+			function := mocks.ByType(functionToken)[0]
+			mode := function.Inputs["tracingConfig"].ObjectValue()["mode"].StringValue()
+			if mode != tc.wantMode {
+				t.Errorf("tracing mode = %s, want %s", mode, tc.wantMode)
+			}
+
+			xrayAttachments := 0
+			for _, a := range mocks.ByType("aws:iam/rolePolicyAttachment:RolePolicyAttachment") {
+				if a.Inputs["policyArn"].StringValue() == "arn:aws:iam::aws:policy/AWSXRayDaemonWriteAccess" {
+					xrayAttachments++
+				}
+			}
+			if tc.enableXRay && xrayAttachments != 1 {
+				t.Error("X-Ray policy attachment missing with EnableXRay")
+			}
+			if !tc.enableXRay && xrayAttachments != 0 {
+				t.Error("X-Ray policy attached without EnableXRay")
+			}
+		})
+	}
+}
+
+func TestArchitectureSetOnFunction(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Architecture = "arm64"
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	architectures := function.Inputs["architectures"].ArrayValue()
+	if len(architectures) != 1 || architectures[0].StringValue() != "arm64" {
+		t.Errorf("architectures = %v, want [arm64]", architectures)
+	}
+}
+
+func TestArchitectureRejectsUnknownValue(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Architecture = "sparc"
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected unknown architecture to be rejected")
+	}
+}
+
+func TestEphemeralStoragePropagates(t *testing.T) {
+	size := 2048
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.EphemeralStorageMB = &size
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	storage := function.Inputs["ephemeralStorage"].ObjectValue()
+	if got := storage["size"].NumberValue(); got != 2048 {
+		t.Errorf("ephemeralStorage.size = %v, want 2048", got)
+	}
+}
+
+func TestEphemeralStorageRejectsOutOfRange(t *testing.T) {
+	size := 256
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.EphemeralStorageMB = &size
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected undersized ephemeral storage to be rejected")
+	}
+}
+
+func TestAliasRoutingWeightsApplied(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.RoutingConfig = map[string]float64{"6": 0.1}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	alias := mocks.ByType(aliasToken)[0]
+	routing := alias.Inputs["routingConfig"].ObjectValue()
+	weights := routing["additionalVersionWeights"].ObjectValue()
+	if got := weights["6"].NumberValue(); got != 0.1 {
+		t.Errorf("weight for version 6 = %v, want 0.1", got)
+	}
+}
+
+func TestAliasRoutingRejectsOverweight(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.RoutingConfig = map[string]float64{"5": 0.6, "6": 0.5}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected weights summing past 1.0 to be rejected")
+	}
+}
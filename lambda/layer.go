@@ -0,0 +1,116 @@
+package lambda
+
+import (
+	"errors"
+	"fmt"
+
+	awslambda "github.com/pulumi/pulumi-aws/sdk/v6/go/aws/lambda"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+// LayerConfig configures a LambdaLayer component.
+type LayerConfig struct {
+	// Code is the layer archive. Required.
+	Code pulumi.ArchiveInput
+	// Description documents what the layer provides.
+	Description string
+	// CompatibleRuntimes restricts which runtimes may use the layer,
+	// e.g. "python3.12". Empty allows any runtime.
+	CompatibleRuntimes []string
+	// CompatibleArchitectures restricts the layer to "x86_64" or
+	// "arm64" functions. Empty allows both.
+	CompatibleArchitectures []string
+	// LicenseInfo is the layer's license identifier or URL.
+	LicenseInfo string
+}
+
+// Validate checks the config for errors that would otherwise surface
+// as opaque AWS API failures. All violations are reported at once.
+func (c *LayerConfig) Validate() error {
+	var errs []error
+	if c.Code == nil {
+		errs = append(errs, fmt.Errorf("Code is required"))
+	}
+	for i, runtime := range c.CompatibleRuntimes {
+		if runtime == "" {
+			errs = append(errs, fmt.Errorf("CompatibleRuntimes[%d]: runtime is required", i))
+		}
+	}
+	for i, arch := range c.CompatibleArchitectures {
+		switch arch {
+		case "x86_64", "arm64":
+		default:
+			errs = append(errs, fmt.Errorf("CompatibleArchitectures[%d]: invalid architecture %q: must be x86_64 or arm64", i, arch))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LambdaLayer is a Lambda layer version. Pass LayerVersionArn to
+// LambdaConfig.LayerARNs to attach it to a function.
+type LambdaLayer struct {
+	pulumi.ResourceState
+
+	// Layer is the underlying layer version resource.
+	Layer *awslambda.LayerVersion
+	// LayerVersionArn is the versioned layer ARN functions attach to.
+	LayerVersionArn pulumi.StringOutput
+	// LayerArn is the unversioned layer ARN.
+	LayerArn pulumi.StringOutput
+}
+
+// NewLambdaLayer creates a Lambda layer version from the given code.
+func NewLambdaLayer(ctx *pulumi.Context, name string, config *LayerConfig, opts ...pulumi.ResourceOption) (*LambdaLayer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &LambdaLayer{}
+	err := ctx.RegisterComponentResource("denecloud:aws:LambdaLayer", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	layerArgs := &awslambda.LayerVersionArgs{
+		LayerName: pulumi.String(name),
+		Code:      config.Code,
+	}
+	if config.Description != "" {
+		layerArgs.Description = pulumi.String(config.Description)
+	}
+	if len(config.CompatibleRuntimes) > 0 {
+		layerArgs.CompatibleRuntimes = pulumi.ToStringArray(config.CompatibleRuntimes)
+	}
+	if len(config.CompatibleArchitectures) > 0 {
+		layerArgs.CompatibleArchitectures = pulumi.ToStringArray(config.CompatibleArchitectures)
+	}
+	if config.LicenseInfo != "" {
+		layerArgs.LicenseInfo = pulumi.String(config.LicenseInfo)
+	}
+	layer, err := awslambda.NewLayerVersion(ctx, name, layerArgs, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Layer = layer
+	comp.LayerVersionArn = layer.Arn
+	comp.LayerArn = layer.LayerArn
+
+	if err := ctx.RegisterResourceOutputs(comp, pulumi.Map{
+		"layerVersionArn": comp.LayerVersionArn,
+		"layerArn":        comp.LayerArn,
+	}); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
@@ -0,0 +1,52 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const layerToken = "aws:lambda/layerVersion:LayerVersion"
+
+func TestLayerVersionArnExported(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		layer, err := NewLambdaLayer(ctx, "deps", &LayerConfig{
+			Code:                    pulumi.NewFileArchive("testdata/handler.zip"),
+			CompatibleRuntimes:      []string{"python3.12"},
+			CompatibleArchitectures: []string{"arm64"},
+		})
+		if err != nil {
+			return err
+		}
+		layer.LayerVersionArn.ApplyT(func(arn string) string {
+			if arn == "" {
+				t.Error("LayerVersionArn is empty")
+			}
+			return arn
+		})
+		return nil
+	})
+
+	layers := mocks.ByType(layerToken)
+	if len(layers) != 1 {
+		t.Fatalf("expected 1 layer version, got %d", len(layers))
+	}
+	runtimes := layers[0].Inputs["compatibleRuntimes"].ArrayValue()
+	if len(runtimes) != 1 || runtimes[0].StringValue() != "python3.12" {
+		t.Errorf("compatibleRuntimes = %v, want [python3.12]", runtimes)
+	}
+	architectures := layers[0].Inputs["compatibleArchitectures"].ArrayValue()
+	if len(architectures) != 1 || architectures[0].StringValue() != "arm64" {
+		t.Errorf("compatibleArchitectures = %v, want [arm64]", architectures)
+	}
+}
+
+func TestLayerRequiresCode(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, err := NewLambdaLayer(ctx, "deps", &LayerConfig{})
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a layer without code to be rejected")
+	}
+}
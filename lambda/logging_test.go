@@ -0,0 +1,59 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	logGroupToken           = "aws:cloudwatch/logGroup:LogGroup"
+	subscriptionFilterToken = "aws:cloudwatch/logSubscriptionFilter:LogSubscriptionFilter"
+)
+
+func TestLogGroupNameOverride(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.LogGroupName = "/custom/handler-logs"
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	logGroup := mocks.ByType(logGroupToken)[0]
+	if got := logGroup.Inputs["name"].StringValue(); got != "/custom/handler-logs" {
+		t.Errorf("log group name = %s, want /custom/handler-logs", got)
+	}
+}
+
+func TestLogSubscriptionFilterWiring(t *testing.T) {
+	streamArn := "arn:aws:kinesis:us-east-1:123456789012:stream/logs"
+	roleArn := "arn:aws:iam::123456789012:role/cw-to-kinesis"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.LogSubscriptionFilter = &LogSubscriptionFilterConfig{
+			DestinationArn: streamArn,
+			FilterPattern:  "{ $.level = \"ERROR\" }",
+			RoleArn:        roleArn,
+		}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	filters := mocks.ByType(subscriptionFilterToken)
+	if len(filters) != 1 {
+		t.Fatalf("expected 1 subscription filter, got %d", len(filters))
+	}
+	filter := filters[0]
+	if got := filter.Inputs["destinationArn"].StringValue(); got != streamArn {
+		t.Errorf("destinationArn = %s, want %s", got, streamArn)
+	}
+	if got := filter.Inputs["filterPattern"].StringValue(); got != "{ $.level = \"ERROR\" }" {
+		t.Errorf("filterPattern = %s", got)
+	}
+	if got := filter.Inputs["roleArn"].StringValue(); got != roleArn {
+		t.Errorf("roleArn = %s, want %s", got, roleArn)
+	}
+	if got := filter.Inputs["logGroup"].StringValue(); got != "/aws/lambda/handler" {
+		t.Errorf("logGroup = %s, want /aws/lambda/handler", got)
+	}
+}
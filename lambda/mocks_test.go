@@ -0,0 +1,43 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/internal/testmocks"
+)
+
+// testMocks wraps the shared recorder and synthesizes the outputs the
+// lambda component reads back during construction.
+type testMocks struct {
+	testmocks.Recorder
+}
+
+func (m *testMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, outputs, err := m.Recorder.NewResource(args)
+	if args.TypeToken == "aws:lambda/function:Function" {
+		outputs["version"] = resource.NewStringProperty("7")
+	}
+	return id, outputs, err
+}
+
+// runTest executes a Pulumi program against the mock monitor and fails
+// the test if the program errors.
+func runTest(t *testing.T, program pulumi.RunFunc) *testMocks {
+	t.Helper()
+	mocks := &testMocks{}
+	testmocks.Run(t, mocks, program)
+	return mocks
+}
+
+// testConfig returns a minimal valid config for tests to extend.
+func testConfig() *LambdaConfig {
+	return &LambdaConfig{
+		Environment: "dev",
+		Runtime:     "go1.x",
+		Handler:     "main",
+		Code:        pulumi.NewFileArchive("testdata/handler.zip"),
+	}
+}
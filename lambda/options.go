@@ -0,0 +1,81 @@
+package lambda
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Option mutates a LambdaConfig. Options are applied in order, so
+// later options override earlier ones.
+type Option func(*LambdaConfig)
+
+// WithEnvironment sets the environment tag, e.g. "dev" or "prod".
+func WithEnvironment(environment string) Option {
+	return func(c *LambdaConfig) { c.Environment = environment }
+}
+
+// WithRuntime sets the runtime and handler for a Zip package.
+func WithRuntime(runtime, handler string) Option {
+	return func(c *LambdaConfig) {
+		c.Runtime = runtime
+		c.Handler = handler
+	}
+}
+
+// WithCode sets the deployment package archive.
+func WithCode(code pulumi.ArchiveInput) Option {
+	return func(c *LambdaConfig) { c.Code = code }
+}
+
+// WithMemory sets the memory size in MB.
+func WithMemory(memoryMB int) Option {
+	return func(c *LambdaConfig) { c.MemoryMB = memoryMB }
+}
+
+// WithTimeout sets the invocation timeout in seconds.
+func WithTimeout(seconds int) Option {
+	return func(c *LambdaConfig) { c.TimeoutSeconds = seconds }
+}
+
+// WithVPC attaches the function to a VPC.
+func WithVPC(vpc *VPCConfig) Option {
+	return func(c *LambdaConfig) { c.VPC = vpc }
+}
+
+// WithXRay enables active X-Ray tracing.
+func WithXRay() Option {
+	return func(c *LambdaConfig) { c.EnableXRay = true }
+}
+
+// WithEnvironmentVariables merges variables into the function
+// environment.
+func WithEnvironmentVariables(vars map[string]string) Option {
+	return func(c *LambdaConfig) {
+		if c.EnvironmentVariables == nil {
+			c.EnvironmentVariables = map[string]string{}
+		}
+		for k, v := range vars {
+			c.EnvironmentVariables[k] = v
+		}
+	}
+}
+
+// WithTags merges tags into the resource tag set.
+func WithTags(tags map[string]string) Option {
+	return func(c *LambdaConfig) {
+		if c.Tags == nil {
+			c.Tags = map[string]string{}
+		}
+		for k, v := range tags {
+			c.Tags[k] = v
+		}
+	}
+}
+
+// NewLambdaFunctionWithOptions builds a LambdaConfig from functional
+// options and creates the function. Equivalent to NewLambdaFunction
+// with a hand-built config.
+func NewLambdaFunctionWithOptions(ctx *pulumi.Context, name string, options ...Option) (*LambdaFunction, error) {
+	config := &LambdaConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return NewLambdaFunction(ctx, name, config)
+}
@@ -0,0 +1,50 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestOptionsCompose(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewLambdaFunctionWithOptions(ctx, "fn",
+			WithEnvironment("dev"),
+			WithRuntime("go1.x", "main"),
+			WithCode(pulumi.NewFileArchive("testdata/handler.zip")),
+			WithMemory(256),
+			WithTimeout(45),
+			WithXRay(),
+		)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["memorySize"].NumberValue(); got != 256 {
+		t.Errorf("memorySize = %v, want 256", got)
+	}
+	if got := function.Inputs["timeout"].NumberValue(); got != 45 {
+		t.Errorf("timeout = %v, want 45", got)
+	}
+	if got := function.Inputs["tracingConfig"].ObjectValue()["mode"].StringValue(); got != "Active" {
+		t.Errorf("tracing mode = %s, want Active", got)
+	}
+}
+
+func TestLaterOptionsOverrideEarlier(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewLambdaFunctionWithOptions(ctx, "fn",
+			WithEnvironment("dev"),
+			WithRuntime("go1.x", "main"),
+			WithCode(pulumi.NewFileArchive("testdata/handler.zip")),
+			WithMemory(128),
+			WithMemory(512),
+		)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["memorySize"].NumberValue(); got != 512 {
+		t.Errorf("memorySize = %v, want the later option's 512", got)
+	}
+}
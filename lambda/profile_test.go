@@ -0,0 +1,67 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/profiles"
+)
+
+func TestProdProfileRaisesMemoryDefaults(t *testing.T) {
+	profiles.Register("prod", profiles.EnvironmentProfile{
+		LambdaMemoryMB:       1024,
+		LambdaTimeoutSeconds: 30,
+	})
+	t.Cleanup(profiles.Clear)
+
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Environment = "prod"
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["memorySize"].NumberValue(); got != 1024 {
+		t.Errorf("memorySize = %v, want the profile default 1024", got)
+	}
+	if got := function.Inputs["timeout"].NumberValue(); got != 30 {
+		t.Errorf("timeout = %v, want the profile default 30", got)
+	}
+}
+
+func TestUnprofiledEnvironmentKeepsBaseDefaults(t *testing.T) {
+	profiles.Register("prod", profiles.EnvironmentProfile{LambdaMemoryMB: 1024})
+	t.Cleanup(profiles.Clear)
+
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Environment = "dev"
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["memorySize"].NumberValue(); got != 128 {
+		t.Errorf("memorySize = %v, want the base default 128", got)
+	}
+}
+
+func TestExplicitMemoryBeatsProfile(t *testing.T) {
+	profiles.Register("prod", profiles.EnvironmentProfile{LambdaMemoryMB: 1024})
+	t.Cleanup(profiles.Clear)
+
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Environment = "prod"
+		config.MemoryMB = 256
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	if got := function.Inputs["memorySize"].NumberValue(); got != 256 {
+		t.Errorf("memorySize = %v, want the configured 256", got)
+	}
+}
@@ -0,0 +1,67 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	attachmentToken = "aws:iam/rolePolicyAttachment:RolePolicyAttachment"
+	rolePolicyToken = "aws:iam/rolePolicy:RolePolicy"
+)
+
+func TestManagedPoliciesAttached(t *testing.T) {
+	readOnly := "arn:aws:iam::aws:policy/AmazonDynamoDBReadOnlyAccess"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ManagedPolicyARNs = []string{readOnly}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	found := false
+	for _, a := range mocks.ByType(attachmentToken) {
+		if a.Inputs["policyArn"].StringValue() == readOnly {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("managed policy %s not attached to role", readOnly)
+	}
+}
+
+func TestInlinePoliciesAttached(t *testing.T) {
+	doc := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.InlinePolicies = map[string]string{"s3-read": doc}
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	found := false
+	for _, p := range mocks.ByType(rolePolicyToken) {
+		if p.Inputs["name"].StringValue() == "s3-read" && p.Inputs["policy"].StringValue() == doc {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("inline policy s3-read not attached to role")
+	}
+}
+
+func TestPermissionsBoundarySetOnRole(t *testing.T) {
+	boundary := "arn:aws:iam::123456789012:policy/boundary"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.PermissionsBoundary = boundary
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	role := mocks.ByType("aws:iam/role:Role")[0]
+	if got := role.Inputs["permissionsBoundary"].StringValue(); got != boundary {
+		t.Errorf("permissionsBoundary = %s, want %s", got, boundary)
+	}
+}
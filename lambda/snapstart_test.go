@@ -0,0 +1,36 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestSnapStartEnabledForJavaRuntime(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Runtime = "java21"
+		config.Handler = "com.example.Handler::handleRequest"
+		config.SnapStart = true
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	})
+
+	function := mocks.ByType(functionToken)[0]
+	snapStart := function.Inputs["snapStart"].ObjectValue()
+	if got := snapStart["applyOn"].StringValue(); got != "PublishedVersions" {
+		t.Errorf("snapStart applyOn = %s, want PublishedVersions", got)
+	}
+}
+
+func TestSnapStartRejectedForNonJavaRuntime(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.SnapStart = true
+		_, err := NewLambdaFunction(ctx, "handler", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected SnapStart on a non-Java runtime to be rejected")
+	}
+}
@@ -0,0 +1,29 @@
+package lambda
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateMissingEnvironment(t *testing.T) {
+	config := &LambdaConfig{Runtime: "go1.x"}
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), "Environment is required") {
+		t.Errorf("Validate() = %v, want Environment is required", err)
+	}
+}
+
+func TestValidateInvalidRuntime(t *testing.T) {
+	config := &LambdaConfig{Environment: "dev", Runtime: "cobol85"}
+	err := config.Validate()
+	if err == nil || !strings.Contains(err.Error(), `invalid Runtime "cobol85"`) {
+		t.Errorf("Validate() = %v, want invalid Runtime", err)
+	}
+}
+
+func TestValidateImagePackageSkipsRuntime(t *testing.T) {
+	config := &LambdaConfig{Environment: "dev", PackageType: "Image"}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil for Image package without a runtime", err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package profiles lets callers register per-environment defaults that
+// constructors consult when a config field is zero-valued, so e.g.
+// "prod" can default to larger Lambda memory than "dev" without every
+// config repeating the numbers.
+package profiles
+
+import "sync"
+
+// EnvironmentProfile holds default values applied to zero-valued config
+// fields for a given environment. A zero field in the profile falls
+// through to the package's own base default.
+type EnvironmentProfile struct {
+	// LambdaMemoryMB overrides the 128 MB Lambda memory default.
+	LambdaMemoryMB int
+	// LambdaTimeoutSeconds overrides the 3 second Lambda timeout
+	// default.
+	LambdaTimeoutSeconds int
+	// LogRetentionDays overrides the 30 day log retention default.
+	LogRetentionDays int
+	// CloudFrontPriceClass overrides the PriceClass_100 default.
+	CloudFrontPriceClass string
+}
+
+var (
+	mu       sync.RWMutex
+	registry = map[string]EnvironmentProfile{}
+)
+
+// Register associates a profile with an environment name, replacing any
+// previously registered profile. Typically called once at program
+// start, before constructing components.
+func Register(environment string, profile EnvironmentProfile) {
+	mu.Lock()
+	defer mu.Unlock()
+	registry[environment] = profile
+}
+
+// Clear removes all registered profiles. Mainly for tests.
+func Clear() {
+	mu.Lock()
+	defer mu.Unlock()
+	registry = map[string]EnvironmentProfile{}
+}
+
+// Lookup returns the profile registered for an environment, or the zero
+// profile when none is registered so callers can fall through to their
+// base defaults unconditionally.
+func Lookup(environment string) EnvironmentProfile {
+	mu.RLock()
+	defer mu.RUnlock()
+	return registry[environment]
+}
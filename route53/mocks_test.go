@@ -0,0 +1,50 @@
+package route53
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/internal/testmocks"
+)
+
+const (
+	zoneToken   = "aws:route53/zone:Zone"
+	recordToken = "aws:route53/record:Record"
+)
+
+// testMocks wraps the shared recorder and synthesizes the zone id and
+// name servers the component reads off created zones.
+type testMocks struct {
+	testmocks.Recorder
+}
+
+func (m *testMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, outputs, err := m.Recorder.NewResource(args)
+	if args.TypeToken == zoneToken {
+		outputs["zoneId"] = resource.NewStringProperty("Z" + args.Name)
+		outputs["nameServers"] = resource.NewArrayProperty([]resource.PropertyValue{
+			resource.NewStringProperty("ns-1.awsdns-01.org"),
+			resource.NewStringProperty("ns-2.awsdns-02.net"),
+		})
+	}
+	return id, outputs, err
+}
+
+// runTest executes a Pulumi program against the mock monitor and fails
+// the test if the program errors.
+func runTest(t *testing.T, program pulumi.RunFunc) *testMocks {
+	t.Helper()
+	mocks := &testMocks{}
+	testmocks.Run(t, mocks, program)
+	return mocks
+}
+
+// testConfig returns a minimal valid config for tests to extend.
+func testConfig() *HostedZoneConfig {
+	return &HostedZoneConfig{
+		Environment: "dev",
+		DomainName:  "example.com",
+	}
+}
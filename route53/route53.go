@@ -0,0 +1,201 @@
+// Package route53 provides a reusable Pulumi component for Route 53
+// hosted zones and alias records pointing at AWS-managed targets such
+// as CloudFront distributions, API Gateway domains and ALBs.
+package route53
+
+import (
+	"errors"
+	"fmt"
+
+	awsroute53 "github.com/pulumi/pulumi-aws/sdk/v6/go/aws/route53"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// CloudFrontZoneId is the fixed hosted zone id shared by every
+// CloudFront distribution, for use as an alias target zone id.
+const CloudFrontZoneId = "Z2FDTNDATAQYW2"
+
+// AliasTargetConfig identifies the AWS resource an alias record points
+// at.
+type AliasTargetConfig struct {
+	// DomainName is the target's DNS name, e.g. a CloudFront
+	// distribution's domain name or an ALB's DNS name.
+	DomainName pulumi.StringInput
+	// ZoneId is the target's hosted zone id. CloudFront distributions
+	// share CloudFrontZoneId; API Gateway custom domains and ALBs
+	// expose theirs as outputs.
+	ZoneId pulumi.StringInput
+	// EvaluateTargetHealth routes traffic away from unhealthy targets.
+	EvaluateTargetHealth bool
+}
+
+// AliasRecordConfig is one alias record in the zone.
+type AliasRecordConfig struct {
+	// Name is the record name, e.g. "www.example.com". Empty creates
+	// the record at the zone apex.
+	Name string
+	// Type is A (default) or AAAA.
+	Type string
+	// Target is the AWS resource the record aliases to.
+	Target AliasTargetConfig
+}
+
+// validate checks the record type and alias target.
+func (rc *AliasRecordConfig) validate() error {
+	switch rc.Type {
+	case "", "A", "AAAA":
+	default:
+		return fmt.Errorf("invalid record Type %q: alias records must be A or AAAA", rc.Type)
+	}
+	if rc.Target.DomainName == nil {
+		return fmt.Errorf("Target.DomainName is required")
+	}
+	if rc.Target.ZoneId == nil {
+		return fmt.Errorf("Target.ZoneId is required")
+	}
+	return nil
+}
+
+// HostedZoneConfig configures a HostedZone component.
+type HostedZoneConfig struct {
+	// Environment tags every resource, e.g. "dev" or "prod".
+	Environment string
+	// DomainName is the zone's domain, e.g. "example.com".
+	DomainName string
+	// Comment describes the zone in the Route 53 console.
+	Comment string
+	// AliasRecords creates alias records in the zone.
+	AliasRecords []AliasRecordConfig
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// Validate checks required fields and the alias records before any
+// resources are created, returning every problem found as one joined
+// error.
+func (c *HostedZoneConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	if c.DomainName == "" {
+		errs = append(errs, fmt.Errorf("DomainName is required"))
+	}
+	for i, record := range c.AliasRecords {
+		if err := record.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("AliasRecords[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// HostedZone is a Route 53 hosted zone with its alias records.
+type HostedZone struct {
+	pulumi.ResourceState
+
+	// Zone is the underlying hosted zone.
+	Zone *awsroute53.Zone
+	// ZoneId is the hosted zone id, the value other components need to
+	// create records in the zone.
+	ZoneId pulumi.StringOutput
+	// NameServers are the zone's delegation name servers, to configure
+	// at the domain registrar.
+	NameServers pulumi.StringArrayOutput
+}
+
+// NewHostedZone creates a hosted zone and its alias records.
+func NewHostedZone(ctx *pulumi.Context, name string, config *HostedZoneConfig, opts ...pulumi.ResourceOption) (*HostedZone, error) {
+	if config == nil {
+		config = &HostedZoneConfig{}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	comp := &HostedZone{}
+	if err := ctx.RegisterComponentResource("denecloud:aws:HostedZone", name, comp, opts...); err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	zone, err := awsroute53.NewZone(ctx, name, &awsroute53.ZoneArgs{
+		Name:    pulumi.String(config.DomainName),
+		Comment: pulumi.String(config.Comment),
+		Tags:    tags.MergeTags(config.Environment, config.Tags),
+	}, parentOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating hosted zone: %w", err)
+	}
+	comp.Zone = zone
+	comp.ZoneId = zone.ZoneId
+	comp.NameServers = zone.NameServers
+
+	for i, record := range config.AliasRecords {
+		recordName := record.Name
+		if recordName == "" {
+			recordName = config.DomainName
+		}
+		record.Name = recordName
+		if err := buildAliasRecord(ctx, fmt.Sprintf("%s-record-%d", name, i), zone.ZoneId, record, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ctx.RegisterResourceOutputs(comp, pulumi.Map{
+		"zoneId":      comp.ZoneId,
+		"nameServers": comp.NameServers,
+	}); err != nil {
+		return nil, err
+	}
+	return comp, nil
+}
+
+// NewAliasRecord creates one alias record in an existing hosted zone,
+// e.g. one looked up outside this package. Records in a zone created
+// here can use HostedZoneConfig.AliasRecords instead.
+func NewAliasRecord(ctx *pulumi.Context, name string, zoneId pulumi.StringInput, record AliasRecordConfig, opts ...pulumi.ResourceOption) error {
+	if record.Name == "" {
+		return fmt.Errorf("invalid configuration: Name is required")
+	}
+	if err := record.validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return buildAliasRecord(ctx, name, zoneId, record, opts)
+}
+
+// buildAliasRecord creates the record resource for an already
+// validated config.
+func buildAliasRecord(ctx *pulumi.Context, resourceName string, zoneId pulumi.StringInput, record AliasRecordConfig, opts []pulumi.ResourceOption) error {
+	recordType := record.Type
+	if recordType == "" {
+		recordType = "A"
+	}
+	_, err := awsroute53.NewRecord(ctx, resourceName, &awsroute53.RecordArgs{
+		Name:   pulumi.String(record.Name),
+		Type:   pulumi.String(recordType),
+		ZoneId: zoneId,
+		Aliases: awsroute53.RecordAliasArray{
+			&awsroute53.RecordAliasArgs{
+				Name:                 record.Target.DomainName,
+				ZoneId:               record.Target.ZoneId,
+				EvaluateTargetHealth: pulumi.Bool(record.Target.EvaluateTargetHealth),
+			},
+		},
+	}, opts...)
+	if err != nil {
+		return fmt.Errorf("creating alias record: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package route53
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestZoneWithCloudFrontAliasRecord(t *testing.T) {
+	nameServers := 0
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.AliasRecords = []AliasRecordConfig{
+			{
+				Name: "www.example.com",
+				Target: AliasTargetConfig{
+					DomainName: pulumi.String("d111111abcdef8.cloudfront.net"),
+					ZoneId:     pulumi.String(CloudFrontZoneId),
+				},
+			},
+		}
+		zone, err := NewHostedZone(ctx, "main", config)
+		if err != nil {
+			return err
+		}
+		zone.NameServers.ApplyT(func(servers []string) int {
+			nameServers = len(servers)
+			return 0
+		})
+		return nil
+	})
+
+	zones := mocks.ByType(zoneToken)
+	if len(zones) != 1 {
+		t.Fatalf("expected 1 hosted zone, got %d", len(zones))
+	}
+	if got := zones[0].Inputs["name"].StringValue(); got != "example.com" {
+		t.Errorf("zone name = %s, want example.com", got)
+	}
+
+	records := mocks.ByType(recordToken)
+	if len(records) != 1 {
+		t.Fatalf("expected 1 record, got %d", len(records))
+	}
+	inputs := records[0].Inputs
+	if got := inputs["type"].StringValue(); got != "A" {
+		t.Errorf("record type = %s, want the A default", got)
+	}
+	if got := inputs["name"].StringValue(); got != "www.example.com" {
+		t.Errorf("record name = %s, want www.example.com", got)
+	}
+	alias := inputs["aliases"].ArrayValue()[0].ObjectValue()
+	if got := alias["name"].StringValue(); got != "d111111abcdef8.cloudfront.net" {
+		t.Errorf("alias target = %s, want the distribution domain", got)
+	}
+	if got := alias["zoneId"].StringValue(); got != CloudFrontZoneId {
+		t.Errorf("alias zoneId = %s, want %s", got, CloudFrontZoneId)
+	}
+	if nameServers != 2 {
+		t.Errorf("expected 2 name servers, got %d", nameServers)
+	}
+}
+
+func TestApexRecordDefaultsToZoneDomain(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.AliasRecords = []AliasRecordConfig{
+			{
+				Type: "AAAA",
+				Target: AliasTargetConfig{
+					DomainName: pulumi.String("d111111abcdef8.cloudfront.net"),
+					ZoneId:     pulumi.String(CloudFrontZoneId),
+				},
+			},
+		}
+		_, err := NewHostedZone(ctx, "main", config)
+		return err
+	})
+
+	record := mocks.ByType(recordToken)[0]
+	if got := record.Inputs["name"].StringValue(); got != "example.com" {
+		t.Errorf("record name = %s, want the zone apex", got)
+	}
+	if got := record.Inputs["type"].StringValue(); got != "AAAA" {
+		t.Errorf("record type = %s, want AAAA", got)
+	}
+}
+
+func TestInvalidRecordTypeRejected(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.AliasRecords = []AliasRecordConfig{
+			{
+				Type: "CNAME",
+				Target: AliasTargetConfig{
+					DomainName: pulumi.String("d111111abcdef8.cloudfront.net"),
+					ZoneId:     pulumi.String(CloudFrontZoneId),
+				},
+			},
+		}
+		_, err := NewHostedZone(ctx, "main", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a CNAME alias record to be rejected")
+	}
+}
+
+func TestAliasRecordRequiresTargetZone(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		return NewAliasRecord(ctx, "www", pulumi.String("Z123456"), AliasRecordConfig{
+			Name: "www.example.com",
+			Target: AliasTargetConfig{
+				DomainName: pulumi.String("d111111abcdef8.cloudfront.net"),
+			},
+		})
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected an alias record without a target zone id to be rejected")
+	}
+}
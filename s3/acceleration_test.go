@@ -0,0 +1,73 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	accelerateToken     = "aws:s3/bucketAccelerateConfigurationV2:BucketAccelerateConfigurationV2"
+	requestPaymentToken = "aws:s3/bucketRequestPaymentConfigurationV2:BucketRequestPaymentConfigurationV2"
+)
+
+func TestTransferAccelerationEnabled(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.TransferAcceleration = true
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	accelerations := mocks.ByType(accelerateToken)
+	if len(accelerations) != 1 {
+		t.Fatalf("expected 1 accelerate configuration, got %d", len(accelerations))
+	}
+	if got := accelerations[0].Inputs["status"].StringValue(); got != "Enabled" {
+		t.Errorf("status = %s, want Enabled", got)
+	}
+}
+
+func TestRequesterPaysEnabled(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.RequesterPays = true
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	payments := mocks.ByType(requestPaymentToken)
+	if len(payments) != 1 {
+		t.Fatalf("expected 1 request payment configuration, got %d", len(payments))
+	}
+	if got := payments[0].Inputs["payer"].StringValue(); got != "Requester" {
+		t.Errorf("payer = %s, want Requester", got)
+	}
+}
+
+func TestTogglesDefaultOff(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewSecureBucket(ctx, "data", testConfig())
+		return err
+	})
+
+	if got := len(mocks.ByType(accelerateToken)); got != 0 {
+		t.Errorf("expected no accelerate configurations, got %d", got)
+	}
+	if got := len(mocks.ByType(requestPaymentToken)); got != 0 {
+		t.Errorf("expected no request payment configurations, got %d", got)
+	}
+}
+
+func TestTransferAccelerationRejectsDottedNames(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.BucketName = "assets.example.com"
+		config.TransferAcceleration = true
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected acceleration on a dotted bucket name to be rejected")
+	}
+}
@@ -0,0 +1,63 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const corsToken = "aws:s3/bucketCorsConfigurationV2:BucketCorsConfigurationV2"
+
+func TestCORSUploadRule(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.CORSRules = []CORSRuleConfig{
+			{
+				AllowedOrigins: []string{"https://app.example.com"},
+				AllowedMethods: []string{"PUT", "POST"},
+				AllowedHeaders: []string{"*"},
+				ExposeHeaders:  []string{"ETag"},
+				MaxAgeSeconds:  3600,
+			},
+		}
+		_, err := NewSecureBucket(ctx, "uploads", config)
+		return err
+	})
+
+	configs := mocks.ByType(corsToken)
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 CORS configuration, got %d", len(configs))
+	}
+	rules := configs[0].Inputs["corsRules"].ArrayValue()
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 CORS rule, got %d", len(rules))
+	}
+	rule := rules[0].ObjectValue()
+	if got := rule["allowedOrigins"].ArrayValue()[0].StringValue(); got != "https://app.example.com" {
+		t.Errorf("allowed origin = %s, want https://app.example.com", got)
+	}
+	methods := rule["allowedMethods"].ArrayValue()
+	if len(methods) != 2 || methods[0].StringValue() != "PUT" {
+		t.Errorf("allowedMethods = %v, want [PUT POST]", methods)
+	}
+	if got := rule["exposeHeaders"].ArrayValue()[0].StringValue(); got != "ETag" {
+		t.Errorf("exposeHeaders = %v, want [ETag]", got)
+	}
+	if got := rule["maxAgeSeconds"].NumberValue(); got != 3600 {
+		t.Errorf("maxAgeSeconds = %v, want 3600", got)
+	}
+}
+
+func TestCORSRejectsInvalidMethod(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.CORSRules = []CORSRuleConfig{
+			{AllowedOrigins: []string{"*"}, AllowedMethods: []string{"PATCH"}},
+		}
+		_, err := NewSecureBucket(ctx, "uploads", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected PATCH to be rejected")
+	}
+}
@@ -0,0 +1,63 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const encryptionToken = "aws:s3/bucketServerSideEncryptionConfigurationV2:BucketServerSideEncryptionConfigurationV2"
+
+func TestKMSEncryption(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Encryption = &EncryptionConfig{
+			Algorithm:        "aws:kms",
+			KmsKeyArn:        "arn:aws:kms:us-east-1:123456789012:key/abc",
+			BucketKeyEnabled: true,
+		}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	configs := mocks.ByType(encryptionToken)
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 encryption configuration, got %d", len(configs))
+	}
+	rule := configs[0].Inputs["rules"].ArrayValue()[0].ObjectValue()
+	byDefault := rule["applyServerSideEncryptionByDefault"].ObjectValue()
+	if got := byDefault["sseAlgorithm"].StringValue(); got != "aws:kms" {
+		t.Errorf("sseAlgorithm = %s, want aws:kms", got)
+	}
+	if got := byDefault["kmsMasterKeyId"].StringValue(); got != "arn:aws:kms:us-east-1:123456789012:key/abc" {
+		t.Errorf("kmsMasterKeyId = %s", got)
+	}
+	if !rule["bucketKeyEnabled"].BoolValue() {
+		t.Error("bucketKeyEnabled not set")
+	}
+}
+
+func TestDefaultEncryptionIsAES256(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewSecureBucket(ctx, "data", testConfig())
+		return err
+	})
+
+	rule := mocks.ByType(encryptionToken)[0].Inputs["rules"].ArrayValue()[0].ObjectValue()
+	byDefault := rule["applyServerSideEncryptionByDefault"].ObjectValue()
+	if got := byDefault["sseAlgorithm"].StringValue(); got != "AES256" {
+		t.Errorf("sseAlgorithm = %s, want AES256", got)
+	}
+}
+
+func TestKmsKeyRequiresKmsAlgorithm(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Encryption = &EncryptionConfig{KmsKeyArn: "arn:aws:kms:us-east-1:123456789012:key/abc"}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a KMS key without aws:kms to be rejected")
+	}
+}
@@ -0,0 +1,21 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestImportIDReachesBucket(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ImportID = "legacy-assets-bucket"
+		_, err := NewSecureBucket(ctx, "assets", config)
+		return err
+	})
+
+	bucket := mocks.ByType(bucketToken)[0]
+	if got := bucket.ID; got != "legacy-assets-bucket" {
+		t.Errorf("bucket import id = %q, want legacy-assets-bucket", got)
+	}
+}
@@ -0,0 +1,67 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const tieringToken = "aws:s3/bucketIntelligentTieringConfiguration:BucketIntelligentTieringConfiguration"
+
+func TestIntelligentTiering(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.IntelligentTiering = []IntelligentTieringConfig{
+			{Name: "cold-data", Prefix: "archive/", ArchiveDays: 90, DeepArchiveDays: 365},
+		}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	configs := mocks.ByType(tieringToken)
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 tiering configuration, got %d", len(configs))
+	}
+	if got := configs[0].Inputs["name"].StringValue(); got != "cold-data" {
+		t.Errorf("name = %s, want cold-data", got)
+	}
+	if got := configs[0].Inputs["filter"].ObjectValue()["prefix"].StringValue(); got != "archive/" {
+		t.Errorf("filter prefix = %s, want archive/", got)
+	}
+	tiers := configs[0].Inputs["tierings"].ArrayValue()
+	if len(tiers) != 2 {
+		t.Fatalf("expected 2 tiers, got %d", len(tiers))
+	}
+	archive := tiers[0].ObjectValue()
+	if archive["accessTier"].StringValue() != "ARCHIVE_ACCESS" || archive["days"].NumberValue() != 90 {
+		t.Errorf("archive tier = %v, want ARCHIVE_ACCESS after 90 days", archive)
+	}
+	deep := tiers[1].ObjectValue()
+	if deep["accessTier"].StringValue() != "DEEP_ARCHIVE_ACCESS" || deep["days"].NumberValue() != 365 {
+		t.Errorf("deep archive tier = %v, want DEEP_ARCHIVE_ACCESS after 365 days", deep)
+	}
+}
+
+func TestIntelligentTieringRejectsLowThresholds(t *testing.T) {
+	cases := []struct {
+		name    string
+		tiering IntelligentTieringConfig
+	}{
+		{"no tiers", IntelligentTieringConfig{Prefix: "archive/"}},
+		{"archive below 90", IntelligentTieringConfig{ArchiveDays: 30}},
+		{"deep archive below 180", IntelligentTieringConfig{DeepArchiveDays: 90}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				config := testConfig()
+				config.IntelligentTiering = []IntelligentTieringConfig{tc.tiering}
+				_, err := NewSecureBucket(ctx, "data", config)
+				return err
+			}, pulumi.WithMocks("project", "stack", &testMocks{}))
+			if err == nil {
+				t.Fatal("expected invalid tiering config to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	inventoryToken = "aws:s3/inventory:Inventory"
+	analyticsToken = "aws:s3/analyticsConfiguration:AnalyticsConfiguration"
+)
+
+func TestWeeklyInventoryToDestinationBucket(t *testing.T) {
+	const destination = "arn:aws:s3:::inventory-reports"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.InventoryConfigs = []InventoryConfig{
+			{
+				DestinationBucketArn: destination,
+				Frequency:            "Weekly",
+				OptionalFields:       []string{"Size", "StorageClass"},
+			},
+		}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	inventories := mocks.ByType(inventoryToken)
+	if len(inventories) != 1 {
+		t.Fatalf("expected 1 inventory configuration, got %d", len(inventories))
+	}
+	inputs := inventories[0].Inputs
+	bucket := inputs["destination"].ObjectValue()["bucket"].ObjectValue()
+	if got := bucket["bucketArn"].StringValue(); got != destination {
+		t.Errorf("destination bucketArn = %s, want %s", got, destination)
+	}
+	if got := bucket["format"].StringValue(); got != "CSV" {
+		t.Errorf("format = %s, want the CSV default", got)
+	}
+	if got := inputs["schedule"].ObjectValue()["frequency"].StringValue(); got != "Weekly" {
+		t.Errorf("frequency = %s, want Weekly", got)
+	}
+	if got := len(inputs["optionalFields"].ArrayValue()); got != 2 {
+		t.Errorf("expected 2 optional fields, got %d", got)
+	}
+}
+
+func TestAnalyticsExportsToDestinationBucket(t *testing.T) {
+	const destination = "arn:aws:s3:::analytics-reports"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.AnalyticsConfigs = []AnalyticsConfig{
+			{Prefix: "logs/", DestinationBucketArn: destination},
+		}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	analytics := mocks.ByType(analyticsToken)
+	if len(analytics) != 1 {
+		t.Fatalf("expected 1 analytics configuration, got %d", len(analytics))
+	}
+	inputs := analytics[0].Inputs
+	if got := inputs["filter"].ObjectValue()["prefix"].StringValue(); got != "logs/" {
+		t.Errorf("prefix = %s, want logs/", got)
+	}
+	export := inputs["storageClassAnalysis"].ObjectValue()["dataExport"].ObjectValue()
+	bucket := export["destination"].ObjectValue()["s3BucketDestination"].ObjectValue()
+	if got := bucket["bucketArn"].StringValue(); got != destination {
+		t.Errorf("export bucketArn = %s, want %s", got, destination)
+	}
+}
+
+func TestInventoryFrequencyValidated(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.InventoryConfigs = []InventoryConfig{
+			{DestinationBucketArn: "arn:aws:s3:::inventory-reports", Frequency: "Monthly"},
+		}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected an invalid inventory frequency to be rejected")
+	}
+}
@@ -0,0 +1,79 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	bucketToken    = "aws:s3/bucketV2:BucketV2"
+	lifecycleToken = "aws:s3/bucketLifecycleConfigurationV2:BucketLifecycleConfigurationV2"
+)
+
+func TestLifecycleTransitionAndExpiration(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.LifecycleRules = []LifecycleRuleConfig{
+			{
+				ID:             "archive-then-expire",
+				Prefix:         "logs/",
+				Transitions:    []LifecycleTransitionConfig{{Days: 30, StorageClass: "GLACIER"}},
+				ExpirationDays: 365,
+			},
+		}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	configs := mocks.ByType(lifecycleToken)
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 lifecycle configuration, got %d", len(configs))
+	}
+	rules := configs[0].Inputs["rules"].ArrayValue()
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(rules))
+	}
+	rule := rules[0].ObjectValue()
+	if got := rule["id"].StringValue(); got != "archive-then-expire" {
+		t.Errorf("rule id = %s, want archive-then-expire", got)
+	}
+	if got := rule["filter"].ObjectValue()["prefix"].StringValue(); got != "logs/" {
+		t.Errorf("filter prefix = %s, want logs/", got)
+	}
+	transition := rule["transitions"].ArrayValue()[0].ObjectValue()
+	if got := transition["days"].NumberValue(); got != 30 {
+		t.Errorf("transition days = %v, want 30", got)
+	}
+	if got := transition["storageClass"].StringValue(); got != "GLACIER" {
+		t.Errorf("transition storage class = %s, want GLACIER", got)
+	}
+	if got := rule["expiration"].ObjectValue()["days"].NumberValue(); got != 365 {
+		t.Errorf("expiration days = %v, want 365", got)
+	}
+}
+
+func TestLifecycleRuleValidation(t *testing.T) {
+	cases := []struct {
+		name string
+		rule LifecycleRuleConfig
+	}{
+		{"no action", LifecycleRuleConfig{Prefix: "tmp/"}},
+		{"bad storage class", LifecycleRuleConfig{
+			Transitions: []LifecycleTransitionConfig{{Days: 30, StorageClass: "FROZEN"}},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				config := testConfig()
+				config.LifecycleRules = []LifecycleRuleConfig{tc.rule}
+				_, err := NewSecureBucket(ctx, "data", config)
+				return err
+			}, pulumi.WithMocks("project", "stack", &testMocks{}))
+			if err == nil {
+				t.Fatal("expected invalid lifecycle rule to be rejected")
+			}
+		})
+	}
+}
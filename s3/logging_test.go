@@ -0,0 +1,64 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const loggingToken = "aws:s3/bucketLoggingV2:BucketLoggingV2"
+
+func TestAccessLoggingTargetsBucket(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Logging = &LoggingConfig{
+			TargetBucket: "central-audit-logs",
+			TargetPrefix: "data/",
+		}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	configs := mocks.ByType(loggingToken)
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 logging configuration, got %d", len(configs))
+	}
+	if got := configs[0].Inputs["targetBucket"].StringValue(); got != "central-audit-logs" {
+		t.Errorf("targetBucket = %s, want central-audit-logs", got)
+	}
+	if got := configs[0].Inputs["targetPrefix"].StringValue(); got != "data/" {
+		t.Errorf("targetPrefix = %s, want data/", got)
+	}
+}
+
+func TestAccessLoggingAutoCreatesBucket(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Logging = &LoggingConfig{AutoCreateBucket: true}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	if buckets := mocks.ByType(bucketToken); len(buckets) != 2 {
+		t.Fatalf("expected 2 buckets, got %d", len(buckets))
+	}
+	configs := mocks.ByType(loggingToken)
+	if len(configs) != 1 {
+		t.Fatalf("expected 1 logging configuration, got %d", len(configs))
+	}
+	if got := configs[0].Inputs["targetPrefix"].StringValue(); got != "data/" {
+		t.Errorf("targetPrefix = %s, want data/", got)
+	}
+}
+
+func TestAccessLoggingRequiresTarget(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Logging = &LoggingConfig{TargetPrefix: "data/"}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected logging without a target to be rejected")
+	}
+}
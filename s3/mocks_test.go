@@ -0,0 +1,42 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/internal/testmocks"
+)
+
+// testMocks wraps the shared recorder and synthesizes the outputs the
+// bucket component reads back during construction.
+type testMocks struct {
+	testmocks.Recorder
+}
+
+func (m *testMocks) NewResource(args pulumi.MockResourceArgs) (string, resource.PropertyMap, error) {
+	id, outputs, err := m.Recorder.NewResource(args)
+	if args.TypeToken == "aws:s3/bucketV2:BucketV2" {
+		outputs["bucketDomainName"] = resource.NewStringProperty(args.Name + ".s3.amazonaws.com")
+		outputs["bucketRegionalDomainName"] = resource.NewStringProperty(args.Name + ".s3.us-east-1.amazonaws.com")
+	}
+	if args.TypeToken == "aws:s3/bucketWebsiteConfigurationV2:BucketWebsiteConfigurationV2" {
+		outputs["websiteEndpoint"] = resource.NewStringProperty(args.Name + ".s3-website-us-east-1.amazonaws.com")
+	}
+	return id, outputs, err
+}
+
+// runTest executes a Pulumi program against the mock monitor and fails
+// the test if the program errors.
+func runTest(t *testing.T, program pulumi.RunFunc) *testMocks {
+	t.Helper()
+	mocks := &testMocks{}
+	testmocks.Run(t, mocks, program)
+	return mocks
+}
+
+// testConfig returns a minimal valid config for tests to extend.
+func testConfig() *BucketConfig {
+	return &BucketConfig{Environment: "dev"}
+}
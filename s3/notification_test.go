@@ -0,0 +1,118 @@
+package s3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	notificationToken = "aws:s3/bucketNotification:BucketNotification"
+	permissionToken   = "aws:lambda/permission:Permission"
+	queuePolicyToken  = "aws:sqs/queuePolicy:QueuePolicy"
+)
+
+func TestLambdaNotification(t *testing.T) {
+	functionArn := "arn:aws:lambda:us-east-1:123456789012:function:thumbnailer"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Notifications = &NotificationsConfig{
+			LambdaFunctions: []NotificationTargetConfig{
+				{
+					Arn:          functionArn,
+					Events:       []string{"s3:ObjectCreated:*"},
+					FilterPrefix: "uploads/",
+					FilterSuffix: ".jpg",
+				},
+			},
+		}
+		_, err := NewSecureBucket(ctx, "media", config)
+		return err
+	})
+
+	permissions := mocks.ByType(permissionToken)
+	if len(permissions) != 1 {
+		t.Fatalf("expected 1 lambda permission, got %d", len(permissions))
+	}
+	if got := permissions[0].Inputs["principal"].StringValue(); got != "s3.amazonaws.com" {
+		t.Errorf("permission principal = %s, want s3.amazonaws.com", got)
+	}
+	if got := permissions[0].Inputs["function"].StringValue(); got != functionArn {
+		t.Errorf("permission function = %s, want %s", got, functionArn)
+	}
+
+	notifications := mocks.ByType(notificationToken)
+	if len(notifications) != 1 {
+		t.Fatalf("expected 1 bucket notification, got %d", len(notifications))
+	}
+	target := notifications[0].Inputs["lambdaFunctions"].ArrayValue()[0].ObjectValue()
+	if got := target["lambdaFunctionArn"].StringValue(); got != functionArn {
+		t.Errorf("lambdaFunctionArn = %s, want %s", got, functionArn)
+	}
+	if got := target["events"].ArrayValue()[0].StringValue(); got != "s3:ObjectCreated:*" {
+		t.Errorf("events = %v, want [s3:ObjectCreated:*]", got)
+	}
+	if got := target["filterPrefix"].StringValue(); got != "uploads/" {
+		t.Errorf("filterPrefix = %s, want uploads/", got)
+	}
+	if got := target["filterSuffix"].StringValue(); got != ".jpg" {
+		t.Errorf("filterSuffix = %s, want .jpg", got)
+	}
+}
+
+func TestQueueNotificationPolicy(t *testing.T) {
+	queueArn := "arn:aws:sqs:us-east-1:123456789012:ingest"
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Notifications = &NotificationsConfig{
+			SQSQueues: []NotificationTargetConfig{
+				{Arn: queueArn, Events: []string{"s3:ObjectCreated:*"}},
+			},
+		}
+		_, err := NewSecureBucket(ctx, "media", config)
+		return err
+	})
+
+	policies := mocks.ByType(queuePolicyToken)
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 queue policy, got %d", len(policies))
+	}
+	if got := policies[0].Inputs["queueUrl"].StringValue(); got != "https://sqs.us-east-1.amazonaws.com/123456789012/ingest" {
+		t.Errorf("queueUrl = %s", got)
+	}
+	if document := policies[0].Inputs["policy"].StringValue(); !strings.Contains(document, "sqs:SendMessage") {
+		t.Errorf("queue policy missing sqs:SendMessage: %s", document)
+	}
+}
+
+func TestNotificationValidation(t *testing.T) {
+	cases := []struct {
+		name          string
+		notifications *NotificationsConfig
+	}{
+		{"wrong service ARN", &NotificationsConfig{
+			LambdaFunctions: []NotificationTargetConfig{
+				{Arn: "arn:aws:sns:us-east-1:123456789012:topic", Events: []string{"s3:ObjectCreated:*"}},
+			},
+		}},
+		{"no events", &NotificationsConfig{
+			SNSTopics: []NotificationTargetConfig{
+				{Arn: "arn:aws:sns:us-east-1:123456789012:topic"},
+			},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				config := testConfig()
+				config.Notifications = tc.notifications
+				_, err := NewSecureBucket(ctx, "media", config)
+				return err
+			}, pulumi.WithMocks("project", "stack", &testMocks{}))
+			if err == nil {
+				t.Fatal("expected invalid notification config to be rejected")
+			}
+		})
+	}
+}
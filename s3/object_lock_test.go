@@ -0,0 +1,59 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const objectLockToken = "aws:s3/bucketObjectLockConfigurationV2:BucketObjectLockConfigurationV2"
+
+func TestComplianceObjectLock(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ObjectLock = &ObjectLockConfig{Enabled: true, Mode: "COMPLIANCE", Days: 30}
+		_, err := NewSecureBucket(ctx, "records", config)
+		return err
+	})
+
+	bucket := mocks.ByType(bucketToken)[0]
+	if !bucket.Inputs["objectLockEnabled"].BoolValue() {
+		t.Error("objectLockEnabled not set on the bucket")
+	}
+
+	locks := mocks.ByType(objectLockToken)
+	if len(locks) != 1 {
+		t.Fatalf("expected 1 object lock configuration, got %d", len(locks))
+	}
+	retention := locks[0].Inputs["rule"].ObjectValue()["defaultRetention"].ObjectValue()
+	if got := retention["mode"].StringValue(); got != "COMPLIANCE" {
+		t.Errorf("mode = %s, want COMPLIANCE", got)
+	}
+	if got := retention["days"].NumberValue(); got != 30 {
+		t.Errorf("days = %v, want 30", got)
+	}
+}
+
+func TestObjectLockValidation(t *testing.T) {
+	cases := []struct {
+		name string
+		lock *ObjectLockConfig
+	}{
+		{"retention without enabled", &ObjectLockConfig{Mode: "GOVERNANCE", Days: 30}},
+		{"days and years", &ObjectLockConfig{Enabled: true, Mode: "GOVERNANCE", Days: 30, Years: 1}},
+		{"invalid mode", &ObjectLockConfig{Enabled: true, Mode: "LEGAL_HOLD", Days: 30}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				config := testConfig()
+				config.ObjectLock = tc.lock
+				_, err := NewSecureBucket(ctx, "records", config)
+				return err
+			}, pulumi.WithMocks("project", "stack", &testMocks{}))
+			if err == nil {
+				t.Fatal("expected invalid object lock config to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package s3
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Option mutates a BucketConfig. Options are applied in order, so
+// later options override earlier ones.
+type Option func(*BucketConfig)
+
+// WithEnvironment sets the environment tag, e.g. "dev" or "prod".
+func WithEnvironment(environment string) Option {
+	return func(c *BucketConfig) { c.Environment = environment }
+}
+
+// WithBucketName sets an explicit bucket name.
+func WithBucketName(bucketName string) Option {
+	return func(c *BucketConfig) { c.BucketName = bucketName }
+}
+
+// WithEncryption selects the server-side encryption settings.
+func WithEncryption(encryption *EncryptionConfig) Option {
+	return func(c *BucketConfig) { c.Encryption = encryption }
+}
+
+// WithLifecycleRule appends one lifecycle rule.
+func WithLifecycleRule(rule LifecycleRuleConfig) Option {
+	return func(c *BucketConfig) { c.LifecycleRules = append(c.LifecycleRules, rule) }
+}
+
+// WithVersioning overrides the default versioning settings.
+func WithVersioning(versioning *VersioningConfig) Option {
+	return func(c *BucketConfig) { c.Versioning = versioning }
+}
+
+// WithTags merges tags into the resource tag set.
+func WithTags(tags map[string]string) Option {
+	return func(c *BucketConfig) {
+		if c.Tags == nil {
+			c.Tags = map[string]string{}
+		}
+		for k, v := range tags {
+			c.Tags[k] = v
+		}
+	}
+}
+
+// NewSecureBucketWithOptions builds a BucketConfig from functional
+// options and creates the bucket. Equivalent to NewSecureBucket with
+// a hand-built config.
+func NewSecureBucketWithOptions(ctx *pulumi.Context, name string, options ...Option) (*SecureBucket, error) {
+	config := &BucketConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return NewSecureBucket(ctx, name, config)
+}
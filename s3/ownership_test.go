@@ -0,0 +1,69 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	ownershipToken = "aws:s3/bucketOwnershipControls:BucketOwnershipControls"
+	aclToken       = "aws:s3/bucketAclV2:BucketAclV2"
+)
+
+func TestOwnershipModes(t *testing.T) {
+	cases := []struct {
+		name      string
+		ownership string
+		acl       string
+		want      string
+		wantACL   bool
+	}{
+		{"default enforced", "", "", "BucketOwnerEnforced", false},
+		{"preferred with acl", "BucketOwnerPreferred", "log-delivery-write", "BucketOwnerPreferred", true},
+		{"object writer", "ObjectWriter", "", "ObjectWriter", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mocks := runTest(t, func(ctx *pulumi.Context) error {
+				config := testConfig()
+				config.ObjectOwnership = tc.ownership
+				config.ACL = tc.acl
+				_, err := NewSecureBucket(ctx, "data", config)
+				return err
+			})
+
+			controls := mocks.ByType(ownershipToken)
+			if len(controls) != 1 {
+				t.Fatalf("expected 1 ownership controls, got %d", len(controls))
+			}
+			rule := controls[0].Inputs["rule"].ObjectValue()
+			if got := rule["objectOwnership"].StringValue(); got != tc.want {
+				t.Errorf("objectOwnership = %s, want %s", got, tc.want)
+			}
+			acls := mocks.ByType(aclToken)
+			if tc.wantACL {
+				if len(acls) != 1 {
+					t.Fatalf("expected 1 ACL, got %d", len(acls))
+				}
+				if got := acls[0].Inputs["acl"].StringValue(); got != tc.acl {
+					t.Errorf("acl = %s, want %s", got, tc.acl)
+				}
+			} else if len(acls) != 0 {
+				t.Errorf("expected no ACL, got %d", len(acls))
+			}
+		})
+	}
+}
+
+func TestACLRequiresPermissiveOwnership(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ACL = "log-delivery-write"
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected an ACL under BucketOwnerEnforced to be rejected")
+	}
+}
@@ -0,0 +1,189 @@
+package s3
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const policyToken = "aws:s3/bucketPolicy:BucketPolicy"
+
+func TestEnforceTLSPolicy(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.BucketPolicy = &BucketPolicyConfig{EnforceTLS: true}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	policies := mocks.ByType(policyToken)
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 bucket policy, got %d", len(policies))
+	}
+	var document struct {
+		Statement []struct {
+			Sid       string
+			Effect    string
+			Condition map[string]map[string]string
+		}
+	}
+	if err := json.Unmarshal([]byte(policies[0].Inputs["policy"].StringValue()), &document); err != nil {
+		t.Fatalf("unmarshaling policy: %v", err)
+	}
+	if len(document.Statement) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(document.Statement))
+	}
+	statement := document.Statement[0]
+	if statement.Sid != "DenyInsecureTransport" || statement.Effect != "Deny" {
+		t.Errorf("statement = %+v, want DenyInsecureTransport/Deny", statement)
+	}
+	if got := statement.Condition["Bool"]["aws:SecureTransport"]; got != "false" {
+		t.Errorf("SecureTransport condition = %q, want false", got)
+	}
+}
+
+func TestCustomPolicyStatements(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.BucketPolicy = &BucketPolicyConfig{
+			Statements: []PolicyStatementConfig{
+				{
+					Sid:        "CrossAccountRead",
+					Principals: []string{"arn:aws:iam::210987654321:root"},
+					Actions:    []string{"s3:GetObject"},
+				},
+			},
+		}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	document := mocks.ByType(policyToken)[0].Inputs["policy"].StringValue()
+	if !strings.Contains(document, `"arn:aws:iam::210987654321:root"`) {
+		t.Errorf("policy missing cross-account principal: %s", document)
+	}
+	if !strings.Contains(document, `"s3:GetObject"`) {
+		t.Errorf("policy missing action: %s", document)
+	}
+	if !strings.Contains(document, `/*`) {
+		t.Errorf("policy missing default object resource: %s", document)
+	}
+}
+
+func TestRawPolicyDocumentUsedVerbatim(t *testing.T) {
+	raw := `{"Version":"2012-10-17","Statement":[]}`
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.BucketPolicy = &BucketPolicyConfig{Document: raw}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	if got := mocks.ByType(policyToken)[0].Inputs["policy"].StringValue(); got != raw {
+		t.Errorf("policy = %s, want raw document", got)
+	}
+}
+
+func TestBucketPolicyValidation(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy *BucketPolicyConfig
+	}{
+		{"empty", &BucketPolicyConfig{}},
+		{"document with enforce TLS", &BucketPolicyConfig{Document: "{}", EnforceTLS: true}},
+		{"statement without principals", &BucketPolicyConfig{
+			Statements: []PolicyStatementConfig{{Actions: []string{"s3:GetObject"}}},
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+				config := testConfig()
+				config.BucketPolicy = tc.policy
+				_, err := NewSecureBucket(ctx, "data", config)
+				return err
+			}, pulumi.WithMocks("project", "stack", &testMocks{}))
+			if err == nil {
+				t.Fatal("expected invalid bucket policy to be rejected")
+			}
+		})
+	}
+}
+
+func TestRequiredObjectTagsDenyStatement(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.BucketPolicy = &BucketPolicyConfig{RequiredObjectTags: []string{"Environment", "Owner"}}
+		_, err := NewSecureBucket(ctx, "data", config)
+		return err
+	})
+
+	var document struct {
+		Statement []struct {
+			Effect    string
+			Action    string
+			Condition map[string]map[string]string
+		}
+	}
+	raw := mocks.ByType(policyToken)[0].Inputs["policy"].StringValue()
+	if err := json.Unmarshal([]byte(raw), &document); err != nil {
+		t.Fatalf("unmarshaling policy: %v", err)
+	}
+	if len(document.Statement) != 2 {
+		t.Fatalf("expected one deny statement per tag key, got %d", len(document.Statement))
+	}
+	seen := map[string]bool{}
+	for _, statement := range document.Statement {
+		if statement.Effect != "Deny" || statement.Action != "s3:PutObject" {
+			t.Errorf("statement = %+v, want a Deny on s3:PutObject", statement)
+		}
+		seen[statement.Condition["ForAllValues:StringNotEquals"]["s3:RequestObjectTagKeys"]] = true
+	}
+	if !seen["Environment"] || !seen["Owner"] {
+		t.Errorf("conditions cover %v, want Environment and Owner", seen)
+	}
+}
+
+func TestGrantCloudFrontOAC(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		bucket, err := NewSecureBucket(ctx, "assets", testConfig())
+		if err != nil {
+			return err
+		}
+		distributionArn := pulumi.String("arn:aws:cloudfront::123456789012:distribution/E2EXAMPLE").ToStringOutput()
+		return GrantCloudFrontOAC(ctx, "assets", bucket, distributionArn, "")
+	})
+
+	policies := mocks.ByType(policyToken)
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 bucket policy, got %d", len(policies))
+	}
+	var document struct {
+		Statement []struct {
+			Effect    string
+			Action    string
+			Principal struct {
+				Service string
+			}
+			Condition map[string]map[string]string
+		}
+	}
+	if err := json.Unmarshal([]byte(policies[0].Inputs["policy"].StringValue()), &document); err != nil {
+		t.Fatalf("unmarshaling policy: %v", err)
+	}
+	if len(document.Statement) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(document.Statement))
+	}
+	statement := document.Statement[0]
+	if statement.Principal.Service != "cloudfront.amazonaws.com" {
+		t.Errorf("principal = %s, want cloudfront.amazonaws.com", statement.Principal.Service)
+	}
+	if statement.Action != "s3:GetObject" {
+		t.Errorf("action = %s, want s3:GetObject", statement.Action)
+	}
+	if got := statement.Condition["StringEquals"]["AWS:SourceArn"]; got != "arn:aws:cloudfront::123456789012:distribution/E2EXAMPLE" {
+		t.Errorf("source arn condition = %q, want the distribution ARN", got)
+	}
+}
@@ -0,0 +1,27 @@
+package s3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestExplicitProviderReachesChildResources(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		target, err := aws.NewProvider(ctx, "target-account", &aws.ProviderArgs{
+			Region: pulumi.String("eu-west-1"),
+		})
+		if err != nil {
+			return err
+		}
+		_, err = NewSecureBucket(ctx, "data", testConfig(), pulumi.Provider(target))
+		return err
+	})
+
+	versioning := mocks.ByType(versioningToken)[0]
+	if !strings.Contains(versioning.Provider, "target-account") {
+		t.Errorf("versioning provider = %q, want the explicit target-account provider", versioning.Provider)
+	}
+}
@@ -0,0 +1,84 @@
+package s3
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const publicAccessBlockToken = "aws:s3/bucketPublicAccessBlock:BucketPublicAccessBlock"
+
+func TestAllowPublicReadRelaxesPolicyBlock(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.AllowPublicRead = &PublicReadConfig{
+			Prefix:                    "public/",
+			AcknowledgePublicExposure: true,
+		}
+		_, err := NewSecureBucket(ctx, "site", config)
+		return err
+	})
+
+	block := mocks.ByType(publicAccessBlockToken)[0]
+	if block.Inputs["blockPublicPolicy"].BoolValue() {
+		t.Error("blockPublicPolicy still true with AllowPublicRead")
+	}
+	if block.Inputs["restrictPublicBuckets"].BoolValue() {
+		t.Error("restrictPublicBuckets still true with AllowPublicRead")
+	}
+	if !block.Inputs["blockPublicAcls"].BoolValue() {
+		t.Error("blockPublicAcls should stay on: public access is policy-based")
+	}
+
+	policies := mocks.ByType(policyToken)
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 bucket policy, got %d", len(policies))
+	}
+	var document struct {
+		Statement []struct {
+			Effect    string
+			Principal string
+			Action    string
+			Resource  string
+		}
+	}
+	if err := json.Unmarshal([]byte(policies[0].Inputs["policy"].StringValue()), &document); err != nil {
+		t.Fatalf("unmarshaling policy: %v", err)
+	}
+	statement := document.Statement[0]
+	if statement.Effect != "Allow" || statement.Principal != "*" || statement.Action != "s3:GetObject" {
+		t.Errorf("statement = %+v, want a public s3:GetObject allow", statement)
+	}
+	if !strings.HasSuffix(statement.Resource, "/public/*") {
+		t.Errorf("resource = %s, want it scoped to the public/ prefix", statement.Resource)
+	}
+}
+
+func TestAllowPublicReadRequiresAcknowledgement(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.AllowPublicRead = &PublicReadConfig{}
+		_, err := NewSecureBucket(ctx, "site", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected AllowPublicRead without acknowledgement to be rejected")
+	}
+}
+
+func TestPublicReadBlockedByDefault(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewSecureBucket(ctx, "data", testConfig())
+		return err
+	})
+
+	block := mocks.ByType(publicAccessBlockToken)[0]
+	for _, field := range []string{"blockPublicAcls", "blockPublicPolicy", "ignorePublicAcls", "restrictPublicBuckets"} {
+		if !block.Inputs[resource.PropertyKey(field)].BoolValue() {
+			t.Errorf("%s should default to true", field)
+		}
+	}
+}
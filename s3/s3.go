@@ -0,0 +1,1493 @@
+// Package s3 provides a reusable Pulumi component for hardened S3
+// buckets with versioning, encryption and public-access blocking on
+// by default.
+package s3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/lambda"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/s3"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sns"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sqs"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// validStorageClasses are the storage classes accepted in lifecycle
+// transitions.
+var validStorageClasses = map[string]bool{
+	"STANDARD_IA":         true,
+	"ONEZONE_IA":          true,
+	"INTELLIGENT_TIERING": true,
+	"GLACIER":             true,
+	"GLACIER_IR":          true,
+	"DEEP_ARCHIVE":        true,
+}
+
+// LifecycleTransitionConfig moves objects to a cheaper storage class a
+// number of days after creation.
+type LifecycleTransitionConfig struct {
+	// Days after object creation when the transition applies.
+	Days int
+	// StorageClass is the target class, e.g. GLACIER or STANDARD_IA.
+	StorageClass string
+}
+
+// LifecycleRuleConfig describes one lifecycle rule. At least one of
+// Transitions, ExpirationDays, NoncurrentVersionExpirationDays or
+// AbortIncompleteMultipartUploadDays must be set.
+type LifecycleRuleConfig struct {
+	// ID identifies the rule. Defaults to "rule-<index>".
+	ID string
+	// Prefix limits the rule to objects under this key prefix. Empty
+	// applies the rule to the whole bucket.
+	Prefix string
+	// Transitions moves current object versions between storage
+	// classes over time.
+	Transitions []LifecycleTransitionConfig
+	// ExpirationDays deletes current object versions this many days
+	// after creation. Zero disables expiration.
+	ExpirationDays int
+	// NoncurrentVersionExpirationDays deletes noncurrent versions this
+	// many days after they are superseded. Zero disables it.
+	NoncurrentVersionExpirationDays int
+	// AbortIncompleteMultipartUploadDays cleans up stalled multipart
+	// uploads after this many days. Zero disables it.
+	AbortIncompleteMultipartUploadDays int
+}
+
+// EncryptionConfig selects the server-side encryption applied to
+// objects at rest.
+type EncryptionConfig struct {
+	// Algorithm is AES256 (default) or aws:kms.
+	Algorithm string
+	// KmsKeyArn is the customer-managed key to encrypt with. Only
+	// valid with the aws:kms algorithm; empty uses the AWS-managed
+	// aws/s3 key.
+	KmsKeyArn string
+	// BucketKeyEnabled turns on S3 bucket keys to reduce KMS request
+	// costs. Only meaningful with aws:kms.
+	BucketKeyEnabled bool
+}
+
+// validCORSMethods are the HTTP methods S3 accepts in CORS rules.
+var validCORSMethods = map[string]bool{
+	"GET":    true,
+	"PUT":    true,
+	"POST":   true,
+	"DELETE": true,
+	"HEAD":   true,
+}
+
+// CORSRuleConfig is one CORS rule, typically used to allow
+// browser-based uploads directly to the bucket.
+type CORSRuleConfig struct {
+	// AllowedOrigins lists the origins allowed to make requests.
+	AllowedOrigins []string
+	// AllowedMethods lists HTTP methods: GET, PUT, POST, DELETE, HEAD.
+	AllowedMethods []string
+	// AllowedHeaders lists headers allowed in preflight requests.
+	AllowedHeaders []string
+	// ExposeHeaders lists response headers exposed to the browser.
+	ExposeHeaders []string
+	// MaxAgeSeconds is how long browsers may cache the preflight
+	// response. Zero leaves the browser default.
+	MaxAgeSeconds int
+}
+
+// PolicyStatementConfig is one statement of a structured bucket
+// policy.
+type PolicyStatementConfig struct {
+	// Sid optionally identifies the statement.
+	Sid string
+	// Effect is Allow (default) or Deny.
+	Effect string
+	// Principals are AWS principal ARNs, or ["*"] for everyone.
+	Principals []string
+	// Actions are the s3 actions the statement covers.
+	Actions []string
+	// Resources the statement applies to. Empty defaults to the
+	// bucket and all objects in it.
+	Resources []string
+	// Conditions maps condition operators to key/value pairs, e.g.
+	// {"Bool": {"aws:SecureTransport": "false"}}.
+	Conditions map[string]map[string]string
+}
+
+// BucketPolicyConfig attaches a resource policy to the bucket. Set
+// either a raw Document or structured Statements; EnforceTLS appends a
+// deny-non-TLS statement to the structured form.
+type BucketPolicyConfig struct {
+	// Document is a complete policy JSON document used verbatim.
+	Document string
+	// Statements build the policy document from structured parts.
+	Statements []PolicyStatementConfig
+	// EnforceTLS adds a statement denying all requests made without
+	// TLS (aws:SecureTransport false).
+	EnforceTLS bool
+	// RequiredObjectTags adds statements denying s3:PutObject requests
+	// that do not carry all of these tag keys, enforcing object
+	// tagging at write time.
+	RequiredObjectTags []string
+}
+
+// NotificationTargetConfig is one destination for bucket event
+// notifications.
+type NotificationTargetConfig struct {
+	// Arn is the ARN of the Lambda function, SNS topic or SQS queue.
+	Arn string
+	// Events are the S3 event types to deliver, e.g.
+	// "s3:ObjectCreated:*".
+	Events []string
+	// FilterPrefix limits notifications to keys with this prefix.
+	FilterPrefix string
+	// FilterSuffix limits notifications to keys with this suffix.
+	FilterSuffix string
+}
+
+// NotificationsConfig fans bucket events out to Lambda functions, SNS
+// topics and SQS queues. The component creates the resource-based
+// permissions each target needs to be invoked by S3.
+type NotificationsConfig struct {
+	// LambdaFunctions are Lambda function targets.
+	LambdaFunctions []NotificationTargetConfig
+	// SNSTopics are SNS topic targets.
+	SNSTopics []NotificationTargetConfig
+	// SQSQueues are SQS queue targets.
+	SQSQueues []NotificationTargetConfig
+}
+
+// IntelligentTieringConfig moves rarely accessed objects into the
+// Intelligent-Tiering archive tiers. At least one of ArchiveDays and
+// DeepArchiveDays must be set.
+type IntelligentTieringConfig struct {
+	// Name identifies the configuration. Defaults to "tiering-<index>".
+	Name string
+	// Prefix limits the configuration to objects under this key
+	// prefix. Empty applies it to the whole bucket.
+	Prefix string
+	// ArchiveDays moves objects to the Archive Access tier after this
+	// many days without access. Must be at least 90.
+	ArchiveDays int
+	// DeepArchiveDays moves objects to the Deep Archive Access tier
+	// after this many days without access. Must be at least 180.
+	DeepArchiveDays int
+}
+
+// InventoryConfig publishes periodic inventory lists of the bucket's
+// objects to a destination bucket.
+type InventoryConfig struct {
+	// Name identifies the configuration. Defaults to
+	// "inventory-<index>".
+	Name string
+	// DestinationBucketArn is the bucket ARN the inventory results are
+	// published to.
+	DestinationBucketArn string
+	// DestinationPrefix is prepended to all published results.
+	DestinationPrefix string
+	// Format is the output format: CSV (default), ORC or Parquet.
+	Format string
+	// Frequency is Daily or Weekly (default).
+	Frequency string
+	// OptionalFields lists extra object metadata columns to include,
+	// e.g. "Size" or "StorageClass".
+	OptionalFields []string
+	// Prefix limits the inventory to objects under this key prefix.
+	Prefix string
+	// IncludeAllVersions lists noncurrent object versions too. The
+	// default covers current versions only.
+	IncludeAllVersions bool
+}
+
+// PublicReadConfig opts a bucket into controlled public reads, e.g.
+// for a public website: BlockPublicPolicy and RestrictPublicBuckets
+// are relaxed and a public s3:GetObject policy is attached, scoped to
+// an optional key prefix. ACLs stay blocked.
+type PublicReadConfig struct {
+	// Prefix scopes the public statement to objects under this key
+	// prefix, e.g. "public/". Empty exposes every object.
+	Prefix string
+	// AcknowledgePublicExposure must be set to confirm the bucket is
+	// intentionally readable by everyone. Validate rejects the config
+	// without it.
+	AcknowledgePublicExposure bool
+}
+
+// AnalyticsConfig runs storage class analysis on the bucket,
+// optionally exporting daily results to a destination bucket.
+type AnalyticsConfig struct {
+	// Name identifies the configuration. Defaults to
+	// "analytics-<index>".
+	Name string
+	// Prefix limits the analysis to objects under this key prefix.
+	Prefix string
+	// DestinationBucketArn optionally exports the analysis results to
+	// this bucket as CSV. Empty keeps the results console-only.
+	DestinationBucketArn string
+	// DestinationPrefix is prepended to exported results.
+	DestinationPrefix string
+}
+
+// VersioningConfig controls bucket versioning. The zero value keeps
+// the historical default of versioning enabled.
+type VersioningConfig struct {
+	// Status is Enabled (default), Suspended or Disabled. Disabled
+	// skips the versioning configuration entirely and is only valid
+	// for buckets that never had versioning.
+	Status string
+	// MFADelete requires MFA to delete object versions. Only valid
+	// with an Enabled status.
+	MFADelete bool
+}
+
+// ObjectLockConfig enables S3 Object Lock (WORM) retention. Object
+// lock can only be enabled at bucket creation and requires versioning.
+type ObjectLockConfig struct {
+	// Enabled turns object lock on for the bucket.
+	Enabled bool
+	// Mode is the default retention mode: GOVERNANCE or COMPLIANCE.
+	// Empty skips the default retention rule.
+	Mode string
+	// Days is the default retention period in days. Exactly one of
+	// Days and Years must be set with a Mode.
+	Days int
+	// Years is the default retention period in years.
+	Years int
+}
+
+// WebsiteConfig turns the bucket into a static website. The bucket
+// stays private by default, which is the right setup when served via
+// CloudFront with an origin access control; set PublicAccess to relax
+// the public-access block for direct website serving.
+type WebsiteConfig struct {
+	// IndexDocument is the index document suffix. Defaults to
+	// "index.html".
+	IndexDocument string
+	// ErrorDocument is the object key returned for 4xx errors.
+	ErrorDocument string
+	// RoutingRulesJSON is an optional raw JSON array of S3 routing
+	// rules describing redirect behavior.
+	RoutingRulesJSON string
+	// PublicAccess disables the public-access block so the website
+	// endpoint can be served directly. Leave false when fronting the
+	// bucket with CloudFront.
+	PublicAccess bool
+}
+
+// LoggingConfig enables S3 server access logging for audit trails.
+// Exactly one of TargetBucket or AutoCreateBucket must be set.
+type LoggingConfig struct {
+	// TargetBucket is the name of an existing bucket to deliver access
+	// logs to.
+	TargetBucket string
+	// TargetPrefix prefixes every delivered log object key.
+	TargetPrefix string
+	// AutoCreateBucket creates a sibling log bucket instead of using
+	// TargetBucket.
+	AutoCreateBucket bool
+}
+
+// BucketConfig configures a SecureBucket.
+type BucketConfig struct {
+	// Environment tags all resources, e.g. "dev" or "prod".
+	Environment string
+	// BucketName optionally sets an explicit bucket name. When empty,
+	// Pulumi auto-names the bucket.
+	BucketName string
+	// Encryption selects the server-side encryption algorithm and key.
+	// Defaults to AES256.
+	Encryption *EncryptionConfig
+	// LifecycleRules manages object transitions and expiry.
+	LifecycleRules []LifecycleRuleConfig
+	// Logging delivers server access logs to another bucket.
+	Logging *LoggingConfig
+	// BucketPolicy attaches a resource policy to the bucket.
+	BucketPolicy *BucketPolicyConfig
+	// CORSRules configures cross-origin access for browser clients.
+	CORSRules []CORSRuleConfig
+	// Notifications delivers object events to SNS/SQS/Lambda targets.
+	Notifications *NotificationsConfig
+	// Website enables static website hosting on the bucket.
+	Website *WebsiteConfig
+	// ObjectOwnership is BucketOwnerEnforced (default),
+	// BucketOwnerPreferred or ObjectWriter. ACL-based integrations
+	// such as CloudFront log delivery need BucketOwnerPreferred.
+	ObjectOwnership string
+	// ACL applies a canned ACL, e.g. "log-delivery-write". Only valid
+	// with an ObjectOwnership mode that permits ACLs.
+	ACL string
+	// ObjectLock enables WORM retention on the bucket.
+	ObjectLock *ObjectLockConfig
+	// Versioning overrides the default of versioning enabled.
+	Versioning *VersioningConfig
+	// IntelligentTiering archives rarely accessed objects.
+	IntelligentTiering []IntelligentTieringConfig
+	// InventoryConfigs publishes periodic object inventory lists for
+	// cost and access analysis.
+	InventoryConfigs []InventoryConfig
+	// AnalyticsConfigs runs storage class analysis on the bucket.
+	AnalyticsConfigs []AnalyticsConfig
+	// TransferAcceleration enables S3 Transfer Acceleration for faster
+	// long-distance uploads. AWS does not support acceleration on
+	// bucket names containing dots.
+	TransferAcceleration bool
+	// RequesterPays makes requesters bear the request and data
+	// transfer costs instead of the bucket owner.
+	RequesterPays bool
+	// AllowPublicRead opts into controlled public reads instead of the
+	// default full public-access block.
+	AllowPublicRead *PublicReadConfig
+	// ImportID adopts an existing bucket instead of creating a new
+	// one, via pulumi.Import. Child resources (versioning, encryption, policies) are
+	// still created and must not already exist.
+	ImportID string
+	// Tags are merged into every created resource.
+	Tags map[string]string
+}
+
+// SecureBucket is an S3 bucket with versioning, AES256 server-side
+// encryption and a full public-access block.
+type SecureBucket struct {
+	pulumi.ResourceState
+
+	// Bucket is the underlying S3 bucket.
+	Bucket *s3.BucketV2
+	// BucketArn is the bucket's ARN.
+	BucketArn pulumi.StringOutput
+	// BucketID is the bucket name.
+	BucketID pulumi.StringOutput
+	// BucketDomainName is the global bucket domain name.
+	BucketDomainName pulumi.StringOutput
+	// BucketRegionalDomainName is the region-specific domain name,
+	// the right value for a CloudFront S3 origin.
+	BucketRegionalDomainName pulumi.StringOutput
+	// WebsiteEndpoint is the S3 website endpoint. Only set when
+	// website hosting is enabled.
+	WebsiteEndpoint pulumi.StringOutput
+}
+
+// Validate checks required fields and enum values before any resources
+// are created, returning every problem found as one joined error.
+func (c *BucketConfig) Validate() error {
+	errs := []error{
+		validateLifecycleRules(c.LifecycleRules),
+		validateEncryption(c.Encryption),
+		validateBucketPolicy(c.BucketPolicy),
+		validateCORSRules(c.CORSRules),
+		validateNotifications(c.Notifications),
+		validateOwnership(c.ObjectOwnership, c.ACL),
+		validateObjectLock(c.ObjectLock),
+		validateVersioning(c.Versioning, c.ObjectLock),
+		validateIntelligentTiering(c.IntelligentTiering),
+		validateInventoryConfigs(c.InventoryConfigs),
+	}
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	if c.TransferAcceleration && strings.Contains(c.BucketName, ".") {
+		errs = append(errs, fmt.Errorf("TransferAcceleration is not supported on bucket names containing dots"))
+	}
+	if c.AllowPublicRead != nil {
+		if !c.AllowPublicRead.AcknowledgePublicExposure {
+			errs = append(errs, fmt.Errorf("AllowPublicRead requires AcknowledgePublicExposure to be set"))
+		}
+		if c.BucketPolicy != nil {
+			errs = append(errs, fmt.Errorf("AllowPublicRead cannot be combined with BucketPolicy: a bucket holds a single policy document"))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// NewSecureBucket creates a locked-down S3 bucket.
+func NewSecureBucket(ctx *pulumi.Context, name string, config *BucketConfig, opts ...pulumi.ResourceOption) (*SecureBucket, error) {
+	if config == nil {
+		config = &BucketConfig{}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	comp := &SecureBucket{}
+	if err := ctx.RegisterComponentResource("denecloud:aws:SecureBucket", name, comp, opts...); err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	resourceTags := tags.MergeTags(config.Environment, config.Tags)
+
+	bucketArgs := &s3.BucketV2Args{
+		Tags: resourceTags,
+	}
+	if config.BucketName != "" {
+		bucketArgs.Bucket = pulumi.String(config.BucketName)
+	}
+	if config.ObjectLock != nil && config.ObjectLock.Enabled {
+		bucketArgs.ObjectLockEnabled = pulumi.Bool(true)
+	}
+	bucketOpts := parentOpts
+	if config.ImportID != "" {
+		bucketOpts = append([]pulumi.ResourceOption{pulumi.Import(pulumi.ID(config.ImportID))}, parentOpts...)
+	}
+	bucket, err := s3.NewBucketV2(ctx, name, bucketArgs, bucketOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating bucket: %w", err)
+	}
+	comp.Bucket = bucket
+	comp.BucketArn = bucket.Arn
+	comp.BucketID = bucket.ID().ToStringOutput()
+	comp.BucketDomainName = bucket.BucketDomainName
+	comp.BucketRegionalDomainName = bucket.BucketRegionalDomainName
+
+	versioningStatus := "Enabled"
+	mfaDelete := false
+	if config.Versioning != nil {
+		if config.Versioning.Status != "" {
+			versioningStatus = config.Versioning.Status
+		}
+		mfaDelete = config.Versioning.MFADelete
+	}
+	if versioningStatus != "Disabled" {
+		versioningConfig := &s3.BucketVersioningV2VersioningConfigurationArgs{
+			Status: pulumi.String(versioningStatus),
+		}
+		if mfaDelete {
+			versioningConfig.MfaDelete = pulumi.String("Enabled")
+		}
+		_, err = s3.NewBucketVersioningV2(ctx, name+"-versioning", &s3.BucketVersioningV2Args{
+			Bucket:                  bucket.ID(),
+			VersioningConfiguration: versioningConfig,
+		}, parentOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating versioning configuration: %w", err)
+		}
+	}
+
+	if err := buildEncryption(ctx, name, bucket, config.Encryption, parentOpts); err != nil {
+		return nil, err
+	}
+
+	blockPublic := config.Website == nil || !config.Website.PublicAccess
+	// Public reads work through a bucket policy, so only the policy
+	// half of the block is relaxed; ACLs stay blocked either way.
+	blockPolicy := blockPublic && config.AllowPublicRead == nil
+	publicAccessBlock, err := s3.NewBucketPublicAccessBlock(ctx, name+"-public-access-block", &s3.BucketPublicAccessBlockArgs{
+		Bucket:                bucket.ID(),
+		BlockPublicAcls:       pulumi.Bool(blockPublic),
+		BlockPublicPolicy:     pulumi.Bool(blockPolicy),
+		IgnorePublicAcls:      pulumi.Bool(blockPublic),
+		RestrictPublicBuckets: pulumi.Bool(blockPolicy),
+	}, parentOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating public access block: %w", err)
+	}
+
+	if len(config.LifecycleRules) > 0 {
+		if err := buildLifecycle(ctx, name, comp, bucket, config.LifecycleRules, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Logging != nil {
+		if err := buildLogging(ctx, name, bucket, config.Logging, resourceTags, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.BucketPolicy != nil {
+		if err := buildBucketPolicy(ctx, name, bucket, publicAccessBlock, config.BucketPolicy, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.AllowPublicRead != nil {
+		if err := buildPublicReadPolicy(ctx, name, bucket, publicAccessBlock, config.AllowPublicRead, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.CORSRules) > 0 {
+		if err := buildCORS(ctx, name, bucket, config.CORSRules, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Notifications != nil {
+		if err := buildNotifications(ctx, name, bucket, config.Notifications, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Website != nil {
+		if err := buildWebsite(ctx, name, comp, bucket, config.Website, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := buildOwnership(ctx, name, bucket, config.ObjectOwnership, config.ACL, parentOpts); err != nil {
+		return nil, err
+	}
+
+	if config.ObjectLock != nil && config.ObjectLock.Enabled {
+		if err := buildObjectLock(ctx, name, bucket, config.ObjectLock, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, tiering := range config.IntelligentTiering {
+		if err := buildIntelligentTiering(ctx, name, bucket, i, tiering, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, inventory := range config.InventoryConfigs {
+		if err := buildInventory(ctx, name, bucket, i, inventory, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, analytics := range config.AnalyticsConfigs {
+		if err := buildAnalytics(ctx, name, bucket, i, analytics, parentOpts); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.TransferAcceleration {
+		_, err = s3.NewBucketAccelerateConfigurationV2(ctx, name+"-accelerate", &s3.BucketAccelerateConfigurationV2Args{
+			Bucket: bucket.ID(),
+			Status: pulumi.String("Enabled"),
+		}, parentOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating accelerate configuration: %w", err)
+		}
+	}
+
+	if config.RequesterPays {
+		_, err = s3.NewBucketRequestPaymentConfigurationV2(ctx, name+"-request-payment", &s3.BucketRequestPaymentConfigurationV2Args{
+			Bucket: bucket.ID(),
+			Payer:  pulumi.String("Requester"),
+		}, parentOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("creating request payment configuration: %w", err)
+		}
+	}
+
+	outputs := pulumi.Map{
+		"bucketArn":                comp.BucketArn,
+		"bucketId":                 comp.BucketID,
+		"bucketDomainName":         comp.BucketDomainName,
+		"bucketRegionalDomainName": comp.BucketRegionalDomainName,
+	}
+	if comp.WebsiteEndpoint != (pulumi.StringOutput{}) {
+		outputs["websiteEndpoint"] = comp.WebsiteEndpoint
+	}
+	if err := ctx.RegisterResourceOutputs(comp, outputs); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
+
+// validateIntelligentTiering checks the archive day thresholds.
+func validateIntelligentTiering(tierings []IntelligentTieringConfig) error {
+	for i, tiering := range tierings {
+		if tiering.ArchiveDays == 0 && tiering.DeepArchiveDays == 0 {
+			return fmt.Errorf("intelligent tiering %d specifies no archive tier", i)
+		}
+		if tiering.ArchiveDays != 0 && tiering.ArchiveDays < 90 {
+			return fmt.Errorf("intelligent tiering %d: ArchiveDays must be at least 90", i)
+		}
+		if tiering.DeepArchiveDays != 0 && tiering.DeepArchiveDays < 180 {
+			return fmt.Errorf("intelligent tiering %d: DeepArchiveDays must be at least 180", i)
+		}
+	}
+	return nil
+}
+
+// buildIntelligentTiering creates one intelligent tiering
+// configuration on the bucket.
+func buildIntelligentTiering(ctx *pulumi.Context, name string, bucket *s3.BucketV2, index int, tiering IntelligentTieringConfig, parentOpts []pulumi.ResourceOption) error {
+	configName := tiering.Name
+	if configName == "" {
+		configName = fmt.Sprintf("tiering-%d", index)
+	}
+	var tiers s3.BucketIntelligentTieringConfigurationTieringArray
+	if tiering.ArchiveDays > 0 {
+		tiers = append(tiers, &s3.BucketIntelligentTieringConfigurationTieringArgs{
+			AccessTier: pulumi.String("ARCHIVE_ACCESS"),
+			Days:       pulumi.Int(tiering.ArchiveDays),
+		})
+	}
+	if tiering.DeepArchiveDays > 0 {
+		tiers = append(tiers, &s3.BucketIntelligentTieringConfigurationTieringArgs{
+			AccessTier: pulumi.String("DEEP_ARCHIVE_ACCESS"),
+			Days:       pulumi.Int(tiering.DeepArchiveDays),
+		})
+	}
+	args := &s3.BucketIntelligentTieringConfigurationArgs{
+		Bucket:   bucket.ID(),
+		Name:     pulumi.String(configName),
+		Tierings: tiers,
+	}
+	if tiering.Prefix != "" {
+		args.Filter = &s3.BucketIntelligentTieringConfigurationFilterArgs{
+			Prefix: pulumi.String(tiering.Prefix),
+		}
+	}
+	_, err := s3.NewBucketIntelligentTieringConfiguration(ctx, fmt.Sprintf("%s-tiering-%d", name, index), args, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating intelligent tiering configuration: %w", err)
+	}
+	return nil
+}
+
+// validateInventoryConfigs checks destinations, formats and schedule
+// frequencies.
+func validateInventoryConfigs(inventories []InventoryConfig) error {
+	for i, inventory := range inventories {
+		if inventory.DestinationBucketArn == "" {
+			return fmt.Errorf("inventory %d specifies no DestinationBucketArn", i)
+		}
+		switch inventory.Frequency {
+		case "", "Daily", "Weekly":
+		default:
+			return fmt.Errorf("inventory %d: invalid Frequency %q: must be Daily or Weekly", i, inventory.Frequency)
+		}
+		switch inventory.Format {
+		case "", "CSV", "ORC", "Parquet":
+		default:
+			return fmt.Errorf("inventory %d: invalid Format %q: must be CSV, ORC or Parquet", i, inventory.Format)
+		}
+	}
+	return nil
+}
+
+// buildInventory creates one inventory configuration on the bucket.
+func buildInventory(ctx *pulumi.Context, name string, bucket *s3.BucketV2, index int, inventory InventoryConfig, parentOpts []pulumi.ResourceOption) error {
+	configName := inventory.Name
+	if configName == "" {
+		configName = fmt.Sprintf("inventory-%d", index)
+	}
+	format := inventory.Format
+	if format == "" {
+		format = "CSV"
+	}
+	frequency := inventory.Frequency
+	if frequency == "" {
+		frequency = "Weekly"
+	}
+	includedVersions := "Current"
+	if inventory.IncludeAllVersions {
+		includedVersions = "All"
+	}
+	destination := &s3.InventoryDestinationBucketArgs{
+		BucketArn: pulumi.String(inventory.DestinationBucketArn),
+		Format:    pulumi.String(format),
+	}
+	if inventory.DestinationPrefix != "" {
+		destination.Prefix = pulumi.String(inventory.DestinationPrefix)
+	}
+	args := &s3.InventoryArgs{
+		Bucket:                 bucket.ID(),
+		Name:                   pulumi.String(configName),
+		IncludedObjectVersions: pulumi.String(includedVersions),
+		Destination:            &s3.InventoryDestinationArgs{Bucket: destination},
+		Schedule:               &s3.InventoryScheduleArgs{Frequency: pulumi.String(frequency)},
+	}
+	if len(inventory.OptionalFields) > 0 {
+		args.OptionalFields = pulumi.ToStringArray(inventory.OptionalFields)
+	}
+	if inventory.Prefix != "" {
+		args.Filter = &s3.InventoryFilterArgs{Prefix: pulumi.String(inventory.Prefix)}
+	}
+	_, err := s3.NewInventory(ctx, fmt.Sprintf("%s-inventory-%d", name, index), args, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating inventory configuration: %w", err)
+	}
+	return nil
+}
+
+// buildAnalytics creates one storage class analysis configuration on
+// the bucket.
+func buildAnalytics(ctx *pulumi.Context, name string, bucket *s3.BucketV2, index int, analytics AnalyticsConfig, parentOpts []pulumi.ResourceOption) error {
+	configName := analytics.Name
+	if configName == "" {
+		configName = fmt.Sprintf("analytics-%d", index)
+	}
+	args := &s3.AnalyticsConfigurationArgs{
+		Bucket: bucket.ID(),
+		Name:   pulumi.String(configName),
+	}
+	if analytics.Prefix != "" {
+		args.Filter = &s3.AnalyticsConfigurationFilterArgs{Prefix: pulumi.String(analytics.Prefix)}
+	}
+	if analytics.DestinationBucketArn != "" {
+		destination := &s3.AnalyticsConfigurationStorageClassAnalysisDataExportDestinationS3BucketDestinationArgs{
+			BucketArn: pulumi.String(analytics.DestinationBucketArn),
+		}
+		if analytics.DestinationPrefix != "" {
+			destination.Prefix = pulumi.String(analytics.DestinationPrefix)
+		}
+		args.StorageClassAnalysis = &s3.AnalyticsConfigurationStorageClassAnalysisArgs{
+			DataExport: &s3.AnalyticsConfigurationStorageClassAnalysisDataExportArgs{
+				Destination: &s3.AnalyticsConfigurationStorageClassAnalysisDataExportDestinationArgs{
+					S3BucketDestination: destination,
+				},
+			},
+		}
+	}
+	_, err := s3.NewAnalyticsConfiguration(ctx, fmt.Sprintf("%s-analytics-%d", name, index), args, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating analytics configuration: %w", err)
+	}
+	return nil
+}
+
+// validateVersioning checks the status and that features requiring
+// versioning are not combined with a non-enabled status.
+func validateVersioning(versioning *VersioningConfig, lock *ObjectLockConfig) error {
+	if versioning == nil {
+		return nil
+	}
+	switch versioning.Status {
+	case "", "Enabled":
+	case "Suspended", "Disabled":
+		if versioning.MFADelete {
+			return fmt.Errorf("MFADelete requires versioning to be Enabled")
+		}
+		if lock != nil && lock.Enabled {
+			return fmt.Errorf("object lock requires versioning to be Enabled")
+		}
+	default:
+		return fmt.Errorf("invalid versioning status %q: must be Enabled, Suspended or Disabled", versioning.Status)
+	}
+	return nil
+}
+
+// validateObjectLock checks the retention mode and period.
+func validateObjectLock(lock *ObjectLockConfig) error {
+	if lock == nil {
+		return nil
+	}
+	if !lock.Enabled {
+		if lock.Mode != "" || lock.Days > 0 || lock.Years > 0 {
+			return fmt.Errorf("object lock retention requires Enabled")
+		}
+		return nil
+	}
+	switch lock.Mode {
+	case "":
+		if lock.Days > 0 || lock.Years > 0 {
+			return fmt.Errorf("object lock retention period requires a Mode")
+		}
+	case "GOVERNANCE", "COMPLIANCE":
+		if (lock.Days > 0) == (lock.Years > 0) {
+			return fmt.Errorf("object lock retention requires exactly one of Days and Years")
+		}
+	default:
+		return fmt.Errorf("invalid object lock mode %q: must be GOVERNANCE or COMPLIANCE", lock.Mode)
+	}
+	return nil
+}
+
+// buildObjectLock creates the object lock configuration with the
+// default retention rule.
+func buildObjectLock(ctx *pulumi.Context, name string, bucket *s3.BucketV2, lock *ObjectLockConfig, parentOpts []pulumi.ResourceOption) error {
+	args := &s3.BucketObjectLockConfigurationV2Args{
+		Bucket: bucket.ID(),
+	}
+	if lock.Mode != "" {
+		retention := &s3.BucketObjectLockConfigurationV2RuleDefaultRetentionArgs{
+			Mode: pulumi.String(lock.Mode),
+		}
+		if lock.Days > 0 {
+			retention.Days = pulumi.Int(lock.Days)
+		}
+		if lock.Years > 0 {
+			retention.Years = pulumi.Int(lock.Years)
+		}
+		args.Rule = &s3.BucketObjectLockConfigurationV2RuleArgs{
+			DefaultRetention: retention,
+		}
+	}
+	_, err := s3.NewBucketObjectLockConfigurationV2(ctx, name+"-object-lock", args, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating object lock configuration: %w", err)
+	}
+	return nil
+}
+
+// validateOwnership checks the ownership mode and that a canned ACL is
+// only combined with a mode that permits ACLs.
+func validateOwnership(ownership, acl string) error {
+	switch ownership {
+	case "", "BucketOwnerEnforced":
+		if acl != "" {
+			return fmt.Errorf("a canned ACL requires ObjectOwnership BucketOwnerPreferred or ObjectWriter")
+		}
+	case "BucketOwnerPreferred", "ObjectWriter":
+	default:
+		return fmt.Errorf("invalid ObjectOwnership %q: must be BucketOwnerEnforced, BucketOwnerPreferred or ObjectWriter", ownership)
+	}
+	return nil
+}
+
+// buildOwnership creates the ownership controls and the optional
+// canned ACL, which must wait for the ownership mode to be in place.
+func buildOwnership(ctx *pulumi.Context, name string, bucket *s3.BucketV2, ownership, acl string, parentOpts []pulumi.ResourceOption) error {
+	if ownership == "" {
+		ownership = "BucketOwnerEnforced"
+	}
+	controls, err := s3.NewBucketOwnershipControls(ctx, name+"-ownership", &s3.BucketOwnershipControlsArgs{
+		Bucket: bucket.ID(),
+		Rule: &s3.BucketOwnershipControlsRuleArgs{
+			ObjectOwnership: pulumi.String(ownership),
+		},
+	}, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating ownership controls: %w", err)
+	}
+	if acl != "" {
+		aclOpts := append([]pulumi.ResourceOption{pulumi.DependsOn([]pulumi.Resource{controls})}, parentOpts...)
+		_, err := s3.NewBucketAclV2(ctx, name+"-acl", &s3.BucketAclV2Args{
+			Bucket: bucket.ID(),
+			Acl:    pulumi.String(acl),
+		}, aclOpts...)
+		if err != nil {
+			return fmt.Errorf("creating bucket ACL: %w", err)
+		}
+	}
+	return nil
+}
+
+// buildWebsite creates the website configuration and exports the
+// website endpoint.
+func buildWebsite(ctx *pulumi.Context, name string, comp *SecureBucket, bucket *s3.BucketV2, website *WebsiteConfig, parentOpts []pulumi.ResourceOption) error {
+	indexDocument := website.IndexDocument
+	if indexDocument == "" {
+		indexDocument = "index.html"
+	}
+	args := &s3.BucketWebsiteConfigurationV2Args{
+		Bucket: bucket.ID(),
+		IndexDocument: &s3.BucketWebsiteConfigurationV2IndexDocumentArgs{
+			Suffix: pulumi.String(indexDocument),
+		},
+	}
+	if website.ErrorDocument != "" {
+		args.ErrorDocument = &s3.BucketWebsiteConfigurationV2ErrorDocumentArgs{
+			Key: pulumi.String(website.ErrorDocument),
+		}
+	}
+	if website.RoutingRulesJSON != "" {
+		args.RoutingRuleDetails = pulumi.String(website.RoutingRulesJSON)
+	}
+	websiteConfig, err := s3.NewBucketWebsiteConfigurationV2(ctx, name+"-website", args, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating website configuration: %w", err)
+	}
+	comp.WebsiteEndpoint = websiteConfig.WebsiteEndpoint
+	return nil
+}
+
+// validateNotifications checks target ARNs point at the right service
+// and that every target lists at least one event.
+func validateNotifications(notifications *NotificationsConfig) error {
+	if notifications == nil {
+		return nil
+	}
+	check := func(kind, arnPrefix string, targets []NotificationTargetConfig) error {
+		for i, target := range targets {
+			if !strings.HasPrefix(target.Arn, arnPrefix) {
+				return fmt.Errorf("%s notification %d: ARN %q is not a %s ARN", kind, i, target.Arn, kind)
+			}
+			if len(target.Events) == 0 {
+				return fmt.Errorf("%s notification %d specifies no events", kind, i)
+			}
+			for _, event := range target.Events {
+				if !strings.HasPrefix(event, "s3:") {
+					return fmt.Errorf("%s notification %d: invalid event %q", kind, i, event)
+				}
+			}
+		}
+		return nil
+	}
+	if err := check("lambda", "arn:aws:lambda:", notifications.LambdaFunctions); err != nil {
+		return err
+	}
+	if err := check("sns", "arn:aws:sns:", notifications.SNSTopics); err != nil {
+		return err
+	}
+	return check("sqs", "arn:aws:sqs:", notifications.SQSQueues)
+}
+
+// notificationPolicy renders a resource policy letting S3 deliver
+// events from the bucket to the target.
+func notificationPolicy(action, targetArn, bucketArn string) (string, error) {
+	document, err := json.Marshal(map[string]any{
+		"Version": "2012-10-17",
+		"Statement": []map[string]any{{
+			"Effect":    "Allow",
+			"Principal": map[string]any{"Service": "s3.amazonaws.com"},
+			"Action":    action,
+			"Resource":  targetArn,
+			"Condition": map[string]any{
+				"ArnLike": map[string]any{"aws:SourceArn": bucketArn},
+			},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling notification policy: %w", err)
+	}
+	return string(document), nil
+}
+
+// queueUrlFromArn derives the SQS queue URL from its ARN.
+func queueUrlFromArn(arn string) (string, error) {
+	parts := strings.Split(arn, ":")
+	if len(parts) != 6 {
+		return "", fmt.Errorf("malformed SQS queue ARN %q", arn)
+	}
+	return fmt.Sprintf("https://sqs.%s.amazonaws.com/%s/%s", parts[3], parts[4], parts[5]), nil
+}
+
+// buildNotifications creates the bucket notification plus the
+// permissions and policies S3 needs to reach each target.
+func buildNotifications(ctx *pulumi.Context, name string, bucket *s3.BucketV2, notifications *NotificationsConfig, parentOpts []pulumi.ResourceOption) error {
+	args := &s3.BucketNotificationArgs{Bucket: bucket.ID()}
+	var grants []pulumi.Resource
+	var lambdaTargets s3.BucketNotificationLambdaFunctionArray
+	var topicTargets s3.BucketNotificationTopicArray
+	var queueTargets s3.BucketNotificationQueueArray
+
+	for i, target := range notifications.LambdaFunctions {
+		permission, err := lambda.NewPermission(ctx, fmt.Sprintf("%s-notify-lambda-%d", name, i), &lambda.PermissionArgs{
+			Action:    pulumi.String("lambda:InvokeFunction"),
+			Function:  pulumi.String(target.Arn),
+			Principal: pulumi.String("s3.amazonaws.com"),
+			SourceArn: bucket.Arn,
+		}, parentOpts...)
+		if err != nil {
+			return fmt.Errorf("creating lambda notification permission: %w", err)
+		}
+		grants = append(grants, permission)
+		functionArgs := &s3.BucketNotificationLambdaFunctionArgs{
+			LambdaFunctionArn: pulumi.String(target.Arn),
+			Events:            pulumi.ToStringArray(target.Events),
+		}
+		if target.FilterPrefix != "" {
+			functionArgs.FilterPrefix = pulumi.String(target.FilterPrefix)
+		}
+		if target.FilterSuffix != "" {
+			functionArgs.FilterSuffix = pulumi.String(target.FilterSuffix)
+		}
+		lambdaTargets = append(lambdaTargets, functionArgs)
+	}
+
+	for i, target := range notifications.SNSTopics {
+		target := target
+		policy := bucket.Arn.ApplyT(func(bucketArn string) (string, error) {
+			return notificationPolicy("sns:Publish", target.Arn, bucketArn)
+		}).(pulumi.StringOutput)
+		topicPolicy, err := sns.NewTopicPolicy(ctx, fmt.Sprintf("%s-notify-topic-%d", name, i), &sns.TopicPolicyArgs{
+			Arn:    pulumi.String(target.Arn),
+			Policy: policy,
+		}, parentOpts...)
+		if err != nil {
+			return fmt.Errorf("creating topic notification policy: %w", err)
+		}
+		grants = append(grants, topicPolicy)
+		topicArgs := &s3.BucketNotificationTopicArgs{
+			TopicArn: pulumi.String(target.Arn),
+			Events:   pulumi.ToStringArray(target.Events),
+		}
+		if target.FilterPrefix != "" {
+			topicArgs.FilterPrefix = pulumi.String(target.FilterPrefix)
+		}
+		if target.FilterSuffix != "" {
+			topicArgs.FilterSuffix = pulumi.String(target.FilterSuffix)
+		}
+		topicTargets = append(topicTargets, topicArgs)
+	}
+
+	for i, target := range notifications.SQSQueues {
+		target := target
+		queueUrl, err := queueUrlFromArn(target.Arn)
+		if err != nil {
+			return err
+		}
+		policy := bucket.Arn.ApplyT(func(bucketArn string) (string, error) {
+			return notificationPolicy("sqs:SendMessage", target.Arn, bucketArn)
+		}).(pulumi.StringOutput)
+		queuePolicy, err := sqs.NewQueuePolicy(ctx, fmt.Sprintf("%s-notify-queue-%d", name, i), &sqs.QueuePolicyArgs{
+			QueueUrl: pulumi.String(queueUrl),
+			Policy:   policy,
+		}, parentOpts...)
+		if err != nil {
+			return fmt.Errorf("creating queue notification policy: %w", err)
+		}
+		grants = append(grants, queuePolicy)
+		queueArgs := &s3.BucketNotificationQueueArgs{
+			QueueArn: pulumi.String(target.Arn),
+			Events:   pulumi.ToStringArray(target.Events),
+		}
+		if target.FilterPrefix != "" {
+			queueArgs.FilterPrefix = pulumi.String(target.FilterPrefix)
+		}
+		if target.FilterSuffix != "" {
+			queueArgs.FilterSuffix = pulumi.String(target.FilterSuffix)
+		}
+		queueTargets = append(queueTargets, queueArgs)
+	}
+
+	if len(lambdaTargets) > 0 {
+		args.LambdaFunctions = lambdaTargets
+	}
+	if len(topicTargets) > 0 {
+		args.Topics = topicTargets
+	}
+	if len(queueTargets) > 0 {
+		args.Queues = queueTargets
+	}
+
+	notificationOpts := append([]pulumi.ResourceOption{pulumi.DependsOn(grants)}, parentOpts...)
+	_, err := s3.NewBucketNotification(ctx, name+"-notifications", args, notificationOpts...)
+	if err != nil {
+		return fmt.Errorf("creating bucket notification: %w", err)
+	}
+	return nil
+}
+
+// validateCORSRules checks each rule names origins and valid methods.
+func validateCORSRules(rules []CORSRuleConfig) error {
+	for i, rule := range rules {
+		if len(rule.AllowedOrigins) == 0 {
+			return fmt.Errorf("CORS rule %d specifies no allowed origins", i)
+		}
+		if len(rule.AllowedMethods) == 0 {
+			return fmt.Errorf("CORS rule %d specifies no allowed methods", i)
+		}
+		for _, method := range rule.AllowedMethods {
+			if !validCORSMethods[method] {
+				return fmt.Errorf("CORS rule %d: invalid method %q", i, method)
+			}
+		}
+	}
+	return nil
+}
+
+// buildCORS creates the bucket CORS configuration.
+func buildCORS(ctx *pulumi.Context, name string, bucket *s3.BucketV2, rules []CORSRuleConfig, parentOpts []pulumi.ResourceOption) error {
+	var ruleArgs s3.BucketCorsConfigurationV2CorsRuleArray
+	for _, rule := range rules {
+		args := &s3.BucketCorsConfigurationV2CorsRuleArgs{
+			AllowedOrigins: pulumi.ToStringArray(rule.AllowedOrigins),
+			AllowedMethods: pulumi.ToStringArray(rule.AllowedMethods),
+		}
+		if len(rule.AllowedHeaders) > 0 {
+			args.AllowedHeaders = pulumi.ToStringArray(rule.AllowedHeaders)
+		}
+		if len(rule.ExposeHeaders) > 0 {
+			args.ExposeHeaders = pulumi.ToStringArray(rule.ExposeHeaders)
+		}
+		if rule.MaxAgeSeconds > 0 {
+			args.MaxAgeSeconds = pulumi.Int(rule.MaxAgeSeconds)
+		}
+		ruleArgs = append(ruleArgs, args)
+	}
+
+	_, err := s3.NewBucketCorsConfigurationV2(ctx, name+"-cors", &s3.BucketCorsConfigurationV2Args{
+		Bucket:    bucket.ID(),
+		CorsRules: ruleArgs,
+	}, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating CORS configuration: %w", err)
+	}
+	return nil
+}
+
+// validateBucketPolicy checks that exactly one policy form is used
+// and that structured statements are complete.
+func validateBucketPolicy(policy *BucketPolicyConfig) error {
+	if policy == nil {
+		return nil
+	}
+	if policy.Document != "" && (len(policy.Statements) > 0 || policy.EnforceTLS || len(policy.RequiredObjectTags) > 0) {
+		return fmt.Errorf("a raw policy Document cannot be combined with Statements, EnforceTLS or RequiredObjectTags")
+	}
+	if policy.Document == "" && len(policy.Statements) == 0 && !policy.EnforceTLS && len(policy.RequiredObjectTags) == 0 {
+		return fmt.Errorf("bucket policy specifies no Document, Statements, EnforceTLS or RequiredObjectTags")
+	}
+	for i, key := range policy.RequiredObjectTags {
+		if key == "" {
+			return fmt.Errorf("RequiredObjectTags[%d] is empty", i)
+		}
+	}
+	for i, statement := range policy.Statements {
+		switch statement.Effect {
+		case "", "Allow", "Deny":
+		default:
+			return fmt.Errorf("policy statement %d: invalid effect %q", i, statement.Effect)
+		}
+		if len(statement.Principals) == 0 {
+			return fmt.Errorf("policy statement %d specifies no principals", i)
+		}
+		if len(statement.Actions) == 0 {
+			return fmt.Errorf("policy statement %d specifies no actions", i)
+		}
+	}
+	return nil
+}
+
+// buildBucketPolicy attaches the resource policy. The policy depends
+// on the public access block so AWS evaluates the block first and a
+// public policy is rejected rather than briefly applied.
+func buildBucketPolicy(ctx *pulumi.Context, name string, bucket *s3.BucketV2, publicAccessBlock *s3.BucketPublicAccessBlock, policy *BucketPolicyConfig, parentOpts []pulumi.ResourceOption) error {
+	var document pulumi.StringInput
+	if policy.Document != "" {
+		document = pulumi.String(policy.Document)
+	} else {
+		statements := policy.Statements
+		if policy.EnforceTLS {
+			statements = append(statements, PolicyStatementConfig{
+				Sid:        "DenyInsecureTransport",
+				Effect:     "Deny",
+				Principals: []string{"*"},
+				Actions:    []string{"s3:*"},
+				Conditions: map[string]map[string]string{
+					"Bool": {"aws:SecureTransport": "false"},
+				},
+			})
+		}
+		requiredTags := policy.RequiredObjectTags
+		document = bucket.Arn.ApplyT(func(arn string) (string, error) {
+			return renderPolicyDocument(arn, statements, requiredTags)
+		}).(pulumi.StringOutput)
+	}
+
+	policyOpts := append([]pulumi.ResourceOption{pulumi.DependsOn([]pulumi.Resource{publicAccessBlock})}, parentOpts...)
+	_, err := s3.NewBucketPolicy(ctx, name+"-policy", &s3.BucketPolicyArgs{
+		Bucket: bucket.ID(),
+		Policy: document,
+	}, policyOpts...)
+	if err != nil {
+		return fmt.Errorf("creating bucket policy: %w", err)
+	}
+	return nil
+}
+
+// buildPublicReadPolicy attaches the public s3:GetObject policy. Like
+// buildBucketPolicy it depends on the public access block so AWS
+// evaluates the (relaxed) block before the policy is applied.
+func buildPublicReadPolicy(ctx *pulumi.Context, name string, bucket *s3.BucketV2, publicAccessBlock *s3.BucketPublicAccessBlock, public *PublicReadConfig, parentOpts []pulumi.ResourceOption) error {
+	prefix := strings.TrimSuffix(public.Prefix, "/")
+	document := bucket.Arn.ApplyT(func(arn string) (string, error) {
+		resource := arn + "/*"
+		if prefix != "" {
+			resource = arn + "/" + prefix + "/*"
+		}
+		policy, err := json.Marshal(map[string]any{
+			"Version": "2012-10-17",
+			"Statement": []map[string]any{{
+				"Sid":       "PublicReadGetObject",
+				"Effect":    "Allow",
+				"Principal": "*",
+				"Action":    "s3:GetObject",
+				"Resource":  resource,
+			}},
+		})
+		if err != nil {
+			return "", fmt.Errorf("marshaling public read policy: %w", err)
+		}
+		return string(policy), nil
+	}).(pulumi.StringOutput)
+
+	policyOpts := append([]pulumi.ResourceOption{pulumi.DependsOn([]pulumi.Resource{publicAccessBlock})}, parentOpts...)
+	_, err := s3.NewBucketPolicy(ctx, name+"-public-read-policy", &s3.BucketPolicyArgs{
+		Bucket: bucket.ID(),
+		Policy: document,
+	}, policyOpts...)
+	if err != nil {
+		return fmt.Errorf("creating public read policy: %w", err)
+	}
+	return nil
+}
+
+// renderPolicyDocument marshals structured statements into a policy
+// JSON document scoped to the bucket, appending one deny statement per
+// required object tag key.
+func renderPolicyDocument(bucketArn string, statements []PolicyStatementConfig, requiredTagKeys []string) (string, error) {
+	var rendered []map[string]any
+	for _, statement := range statements {
+		effect := statement.Effect
+		if effect == "" {
+			effect = "Allow"
+		}
+		resources := statement.Resources
+		if len(resources) == 0 {
+			resources = []string{bucketArn, bucketArn + "/*"}
+		}
+		entry := map[string]any{
+			"Effect":   effect,
+			"Action":   statement.Actions,
+			"Resource": resources,
+		}
+		if statement.Sid != "" {
+			entry["Sid"] = statement.Sid
+		}
+		if len(statement.Principals) == 1 && statement.Principals[0] == "*" {
+			entry["Principal"] = "*"
+		} else {
+			entry["Principal"] = map[string]any{"AWS": statement.Principals}
+		}
+		if len(statement.Conditions) > 0 {
+			entry["Condition"] = statement.Conditions
+		}
+		rendered = append(rendered, entry)
+	}
+	for i, key := range requiredTagKeys {
+		// s3:RequestObjectTagKeys is the set of tag keys on the upload.
+		// ForAllValues:StringNotEquals matches when none of them equals
+		// the required key, and matches vacuously when the upload
+		// carries no tags at all, so each statement denies uploads
+		// missing its key.
+		rendered = append(rendered, map[string]any{
+			"Sid":       fmt.Sprintf("DenyPutObjectMissingRequiredTag%d", i),
+			"Effect":    "Deny",
+			"Principal": "*",
+			"Action":    "s3:PutObject",
+			"Resource":  bucketArn + "/*",
+			"Condition": map[string]any{
+				"ForAllValues:StringNotEquals": map[string]any{
+					"s3:RequestObjectTagKeys": key,
+				},
+			},
+		})
+	}
+	document, err := json.Marshal(map[string]any{
+		"Version":   "2012-10-17",
+		"Statement": rendered,
+	})
+	if err != nil {
+		return "", fmt.Errorf("marshaling bucket policy: %w", err)
+	}
+	return string(document), nil
+}
+
+// GrantCloudFrontOAC attaches the bucket policy an origin access
+// control needs: the CloudFront service principal may read objects,
+// conditioned on requests coming from the given distribution.
+// servicePrincipal overrides the default "cloudfront.amazonaws.com";
+// pass "" to keep it. A bucket only holds one policy document, so this
+// cannot be combined with BucketPolicyConfig on the same bucket.
+func GrantCloudFrontOAC(ctx *pulumi.Context, name string, bucket *SecureBucket, distributionArn pulumi.StringInput, servicePrincipal string) error {
+	if servicePrincipal == "" {
+		servicePrincipal = "cloudfront.amazonaws.com"
+	}
+	document := pulumi.All(bucket.BucketArn, distributionArn).ApplyT(func(args []any) (string, error) {
+		bucketArn := args[0].(string)
+		distArn := args[1].(string)
+		policy, err := json.Marshal(map[string]any{
+			"Version": "2012-10-17",
+			"Statement": []map[string]any{{
+				"Sid":       "AllowCloudFrontServicePrincipal",
+				"Effect":    "Allow",
+				"Principal": map[string]any{"Service": servicePrincipal},
+				"Action":    "s3:GetObject",
+				"Resource":  bucketArn + "/*",
+				"Condition": map[string]any{
+					"StringEquals": map[string]any{"AWS:SourceArn": distArn},
+				},
+			}},
+		})
+		if err != nil {
+			return "", fmt.Errorf("marshaling OAC bucket policy: %w", err)
+		}
+		return string(policy), nil
+	}).(pulumi.StringOutput)
+
+	_, err := s3.NewBucketPolicy(ctx, name+"-oac-policy", &s3.BucketPolicyArgs{
+		Bucket: bucket.Bucket.ID(),
+		Policy: document,
+	}, pulumi.Parent(bucket))
+	if err != nil {
+		return fmt.Errorf("creating OAC bucket policy: %w", err)
+	}
+	return nil
+}
+
+// buildLogging wires server access logging to the target bucket,
+// creating a sibling log bucket when requested.
+func buildLogging(ctx *pulumi.Context, name string, bucket *s3.BucketV2, logging *LoggingConfig, resourceTags pulumi.StringMap, parentOpts []pulumi.ResourceOption) error {
+	var targetBucket pulumi.StringInput
+	switch {
+	case logging.AutoCreateBucket && logging.TargetBucket != "":
+		return fmt.Errorf("only one of TargetBucket and AutoCreateBucket may be set")
+	case logging.AutoCreateBucket:
+		logBucket, err := s3.NewBucketV2(ctx, name+"-logs", &s3.BucketV2Args{
+			Tags: resourceTags,
+		}, parentOpts...)
+		if err != nil {
+			return fmt.Errorf("creating log bucket: %w", err)
+		}
+		targetBucket = logBucket.ID()
+	case logging.TargetBucket != "":
+		targetBucket = pulumi.String(logging.TargetBucket)
+	default:
+		return fmt.Errorf("logging requires a TargetBucket or AutoCreateBucket")
+	}
+
+	prefix := logging.TargetPrefix
+	if prefix == "" {
+		prefix = name + "/"
+	}
+	_, err := s3.NewBucketLoggingV2(ctx, name+"-logging", &s3.BucketLoggingV2Args{
+		Bucket:       bucket.ID(),
+		TargetBucket: targetBucket,
+		TargetPrefix: pulumi.String(prefix),
+	}, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating logging configuration: %w", err)
+	}
+	return nil
+}
+
+// validateEncryption checks the algorithm and that a KMS key is only
+// supplied with aws:kms.
+func validateEncryption(encryption *EncryptionConfig) error {
+	if encryption == nil {
+		return nil
+	}
+	switch encryption.Algorithm {
+	case "", "AES256":
+		if encryption.KmsKeyArn != "" {
+			return fmt.Errorf("KmsKeyArn requires the aws:kms algorithm")
+		}
+	case "aws:kms":
+	default:
+		return fmt.Errorf("invalid encryption algorithm %q: must be AES256 or aws:kms", encryption.Algorithm)
+	}
+	return nil
+}
+
+// buildEncryption creates the server-side encryption configuration.
+func buildEncryption(ctx *pulumi.Context, name string, bucket *s3.BucketV2, encryption *EncryptionConfig, parentOpts []pulumi.ResourceOption) error {
+	if encryption == nil {
+		encryption = &EncryptionConfig{}
+	}
+	algorithm := encryption.Algorithm
+	if algorithm == "" {
+		algorithm = "AES256"
+	}
+	byDefault := &s3.BucketServerSideEncryptionConfigurationV2RuleApplyServerSideEncryptionByDefaultArgs{
+		SseAlgorithm: pulumi.String(algorithm),
+	}
+	if encryption.KmsKeyArn != "" {
+		byDefault.KmsMasterKeyId = pulumi.String(encryption.KmsKeyArn)
+	}
+	rule := &s3.BucketServerSideEncryptionConfigurationV2RuleArgs{
+		ApplyServerSideEncryptionByDefault: byDefault,
+	}
+	if encryption.BucketKeyEnabled {
+		rule.BucketKeyEnabled = pulumi.Bool(true)
+	}
+	_, err := s3.NewBucketServerSideEncryptionConfigurationV2(ctx, name+"-encryption", &s3.BucketServerSideEncryptionConfigurationV2Args{
+		Bucket: bucket.ID(),
+		Rules:  s3.BucketServerSideEncryptionConfigurationV2RuleArray{rule},
+	}, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating encryption configuration: %w", err)
+	}
+	return nil
+}
+
+// validateLifecycleRules checks storage classes and that each rule
+// specifies at least one action.
+func validateLifecycleRules(rules []LifecycleRuleConfig) error {
+	for i, rule := range rules {
+		hasAction := rule.ExpirationDays > 0 ||
+			rule.NoncurrentVersionExpirationDays > 0 ||
+			rule.AbortIncompleteMultipartUploadDays > 0 ||
+			len(rule.Transitions) > 0
+		if !hasAction {
+			return fmt.Errorf("lifecycle rule %d specifies no action", i)
+		}
+		for _, transition := range rule.Transitions {
+			if !validStorageClasses[transition.StorageClass] {
+				return fmt.Errorf("lifecycle rule %d: invalid storage class %q", i, transition.StorageClass)
+			}
+			if transition.Days <= 0 {
+				return fmt.Errorf("lifecycle rule %d: transition days must be positive", i)
+			}
+		}
+	}
+	return nil
+}
+
+// buildLifecycle creates the bucket lifecycle configuration.
+func buildLifecycle(ctx *pulumi.Context, name string, comp *SecureBucket, bucket *s3.BucketV2, rules []LifecycleRuleConfig, parentOpts []pulumi.ResourceOption) error {
+	var ruleArgs s3.BucketLifecycleConfigurationV2RuleArray
+	for i, rule := range rules {
+		id := rule.ID
+		if id == "" {
+			id = fmt.Sprintf("rule-%d", i)
+		}
+		args := &s3.BucketLifecycleConfigurationV2RuleArgs{
+			Id:     pulumi.String(id),
+			Status: pulumi.String("Enabled"),
+		}
+		if rule.Prefix != "" {
+			args.Filter = &s3.BucketLifecycleConfigurationV2RuleFilterArgs{
+				Prefix: pulumi.String(rule.Prefix),
+			}
+		}
+		var transitions s3.BucketLifecycleConfigurationV2RuleTransitionArray
+		for _, transition := range rule.Transitions {
+			transitions = append(transitions, &s3.BucketLifecycleConfigurationV2RuleTransitionArgs{
+				Days:         pulumi.Int(transition.Days),
+				StorageClass: pulumi.String(transition.StorageClass),
+			})
+		}
+		if len(transitions) > 0 {
+			args.Transitions = transitions
+		}
+		if rule.ExpirationDays > 0 {
+			args.Expiration = &s3.BucketLifecycleConfigurationV2RuleExpirationArgs{
+				Days: pulumi.Int(rule.ExpirationDays),
+			}
+		}
+		if rule.NoncurrentVersionExpirationDays > 0 {
+			args.NoncurrentVersionExpiration = &s3.BucketLifecycleConfigurationV2RuleNoncurrentVersionExpirationArgs{
+				NoncurrentDays: pulumi.Int(rule.NoncurrentVersionExpirationDays),
+			}
+		}
+		if rule.AbortIncompleteMultipartUploadDays > 0 {
+			args.AbortIncompleteMultipartUpload = &s3.BucketLifecycleConfigurationV2RuleAbortIncompleteMultipartUploadArgs{
+				DaysAfterInitiation: pulumi.Int(rule.AbortIncompleteMultipartUploadDays),
+			}
+		}
+		ruleArgs = append(ruleArgs, args)
+	}
+
+	_, err := s3.NewBucketLifecycleConfigurationV2(ctx, name+"-lifecycle", &s3.BucketLifecycleConfigurationV2Args{
+		Bucket: bucket.ID(),
+		Rules:  ruleArgs,
+	}, parentOpts...)
+	if err != nil {
+		return fmt.Errorf("creating lifecycle configuration: %w", err)
+	}
+	return nil
+}
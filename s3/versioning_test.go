@@ -0,0 +1,69 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const versioningToken = "aws:s3/bucketVersioningV2:BucketVersioningV2"
+
+func TestVersioningEnabledByDefault(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		_, err := NewSecureBucket(ctx, "data", testConfig())
+		return err
+	})
+
+	versionings := mocks.ByType(versioningToken)
+	if len(versionings) != 1 {
+		t.Fatalf("expected 1 versioning configuration, got %d", len(versionings))
+	}
+	status := versionings[0].Inputs["versioningConfiguration"].ObjectValue()["status"].StringValue()
+	if status != "Enabled" {
+		t.Errorf("status = %s, want Enabled", status)
+	}
+}
+
+func TestVersioningSuspended(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Versioning = &VersioningConfig{Status: "Suspended"}
+		_, err := NewSecureBucket(ctx, "scratch", config)
+		return err
+	})
+
+	versionings := mocks.ByType(versioningToken)
+	if len(versionings) != 1 {
+		t.Fatalf("expected 1 versioning configuration, got %d", len(versionings))
+	}
+	status := versionings[0].Inputs["versioningConfiguration"].ObjectValue()["status"].StringValue()
+	if status != "Suspended" {
+		t.Errorf("status = %s, want Suspended", status)
+	}
+}
+
+func TestVersioningDisabledSkipsResource(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Versioning = &VersioningConfig{Status: "Disabled"}
+		_, err := NewSecureBucket(ctx, "scratch", config)
+		return err
+	})
+
+	if versionings := mocks.ByType(versioningToken); len(versionings) != 0 {
+		t.Errorf("expected no versioning configuration, got %d", len(versionings))
+	}
+}
+
+func TestObjectLockRequiresVersioning(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Versioning = &VersioningConfig{Status: "Disabled"}
+		config.ObjectLock = &ObjectLockConfig{Enabled: true}
+		_, err := NewSecureBucket(ctx, "records", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected object lock without versioning to be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const websiteToken = "aws:s3/bucketWebsiteConfigurationV2:BucketWebsiteConfigurationV2"
+
+func TestWebsiteConfiguration(t *testing.T) {
+	var endpoint string
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Website = &WebsiteConfig{ErrorDocument: "error.html"}
+		bucket, err := NewSecureBucket(ctx, "site", config)
+		if err != nil {
+			return err
+		}
+		bucket.WebsiteEndpoint.ApplyT(func(e string) string {
+			endpoint = e
+			return e
+		})
+		return nil
+	})
+
+	websites := mocks.ByType(websiteToken)
+	if len(websites) != 1 {
+		t.Fatalf("expected 1 website configuration, got %d", len(websites))
+	}
+	if got := websites[0].Inputs["indexDocument"].ObjectValue()["suffix"].StringValue(); got != "index.html" {
+		t.Errorf("index document = %s, want index.html", got)
+	}
+	if got := websites[0].Inputs["errorDocument"].ObjectValue()["key"].StringValue(); got != "error.html" {
+		t.Errorf("error document = %s, want error.html", got)
+	}
+	if endpoint == "" {
+		t.Error("WebsiteEndpoint output not populated")
+	}
+}
+
+func TestWebsitePublicAccessRelaxesBlock(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Website = &WebsiteConfig{PublicAccess: true}
+		_, err := NewSecureBucket(ctx, "site", config)
+		return err
+	})
+
+	block := mocks.ByType("aws:s3/bucketPublicAccessBlock:BucketPublicAccessBlock")[0]
+	if block.Inputs["blockPublicPolicy"].BoolValue() {
+		t.Error("blockPublicPolicy still true for a public website bucket")
+	}
+	if block.Inputs["restrictPublicBuckets"].BoolValue() {
+		t.Error("restrictPublicBuckets still true for a public website bucket")
+	}
+}
@@ -0,0 +1,66 @@
+package sns
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Option mutates a TopicConfig. Options are applied in order, so later
+// options override earlier ones.
+type Option func(*TopicConfig)
+
+// WithEnvironment sets the environment tag, e.g. "dev" or "prod".
+func WithEnvironment(environment string) Option {
+	return func(c *TopicConfig) { c.Environment = environment }
+}
+
+// WithDisplayName sets the name shown in notifications.
+func WithDisplayName(displayName string) Option {
+	return func(c *TopicConfig) { c.DisplayName = displayName }
+}
+
+// WithEncryption enables server-side encryption with the AWS-managed
+// SNS key.
+func WithEncryption() Option {
+	return func(c *TopicConfig) { c.Encrypted = true }
+}
+
+// WithKmsKey encrypts messages with a customer-managed KMS key.
+func WithKmsKey(keyID string) Option {
+	return func(c *TopicConfig) { c.KmsMasterKeyId = keyID }
+}
+
+// WithSubscription appends a subscription for the given protocol and
+// endpoint.
+func WithSubscription(protocol, endpoint string) Option {
+	return func(c *TopicConfig) {
+		c.Subscriptions = append(c.Subscriptions, SubscriptionConfig{Protocol: protocol, Endpoint: endpoint})
+	}
+}
+
+// WithPublishService grants an AWS service principal permission to
+// publish to the topic.
+func WithPublishService(service string) Option {
+	return func(c *TopicConfig) {
+		c.AllowPublishServices = append(c.AllowPublishServices, service)
+	}
+}
+
+// WithTags merges tags into the resource tag set.
+func WithTags(tags map[string]string) Option {
+	return func(c *TopicConfig) {
+		if c.Tags == nil {
+			c.Tags = map[string]string{}
+		}
+		for k, v := range tags {
+			c.Tags[k] = v
+		}
+	}
+}
+
+// NewTopicWithOptions builds a TopicConfig from functional options and
+// creates the topic. Equivalent to NewTopic with a hand-built config.
+func NewTopicWithOptions(ctx *pulumi.Context, name string, options ...Option) (*Topic, error) {
+	config := &TopicConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return NewTopic(ctx, name, config)
+}
@@ -0,0 +1,220 @@
+// Package sns provides a reusable Pulumi component for SNS topics with
+// encryption, subscriptions and service publish policies.
+package sns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sns"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// SubscriptionConfig subscribes an endpoint to the topic.
+type SubscriptionConfig struct {
+	// Protocol is one of email, sqs, lambda or https.
+	Protocol string
+	// Endpoint is the delivery target: an email address, queue ARN,
+	// function ARN or HTTPS URL depending on the protocol.
+	Endpoint string
+	// RawMessageDelivery skips the SNS envelope. Only valid for sqs and
+	// https subscriptions.
+	RawMessageDelivery bool
+}
+
+// TopicConfig configures a Topic component.
+type TopicConfig struct {
+	// Environment tags every resource, e.g. "dev" or "prod".
+	Environment string
+	// DisplayName is shown in SMS and email notifications.
+	DisplayName string
+	// Encrypted enables server-side encryption with the AWS-managed
+	// SNS key. Use KmsMasterKeyId for a customer-managed key.
+	Encrypted bool
+	// KmsMasterKeyId encrypts messages with a customer-managed KMS key,
+	// given as a key id, ARN or alias. Implies Encrypted.
+	KmsMasterKeyId string
+	// Subscriptions are created alongside the topic. Email
+	// subscriptions stay pending until the recipient confirms.
+	Subscriptions []SubscriptionConfig
+	// AllowPublishServices grants the listed AWS service principals
+	// (e.g. "cloudwatch.amazonaws.com") permission to publish to the
+	// topic, scoped to this account.
+	AllowPublishServices []string
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// Validate checks the config for errors that would otherwise surface
+// as opaque AWS API failures. All violations are reported at once.
+func (c *TopicConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	for i, sub := range c.Subscriptions {
+		if sub.Endpoint == "" {
+			errs = append(errs, fmt.Errorf("Subscriptions[%d]: Endpoint is required", i))
+		}
+		switch sub.Protocol {
+		case "email":
+			if sub.Endpoint != "" && !strings.Contains(sub.Endpoint, "@") {
+				errs = append(errs, fmt.Errorf("Subscriptions[%d]: email endpoint %q is not an address", i, sub.Endpoint))
+			}
+		case "sqs", "lambda":
+			if sub.Endpoint != "" && !strings.HasPrefix(sub.Endpoint, "arn:") {
+				errs = append(errs, fmt.Errorf("Subscriptions[%d]: %s endpoint must be an ARN, got %q", i, sub.Protocol, sub.Endpoint))
+			}
+		case "https":
+			if sub.Endpoint != "" && !strings.HasPrefix(sub.Endpoint, "https://") {
+				errs = append(errs, fmt.Errorf("Subscriptions[%d]: https endpoint must be an https:// URL, got %q", i, sub.Endpoint))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("Subscriptions[%d]: invalid Protocol %q: must be email, sqs, lambda or https", i, sub.Protocol))
+		}
+		if sub.RawMessageDelivery && sub.Protocol != "sqs" && sub.Protocol != "https" {
+			errs = append(errs, fmt.Errorf("Subscriptions[%d]: RawMessageDelivery is only valid for sqs and https", i))
+		}
+	}
+	for i, service := range c.AllowPublishServices {
+		if service == "" {
+			errs = append(errs, fmt.Errorf("AllowPublishServices[%d]: service principal is required", i))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Topic is an SNS topic with its subscriptions and, when services are
+// granted publish access, a topic policy. Pass TopicArn to alarm
+// notification and bucket notification configs.
+type Topic struct {
+	pulumi.ResourceState
+
+	// Topic is the underlying SNS topic resource.
+	Topic *sns.Topic
+	// TopicArn is the topic ARN.
+	TopicArn pulumi.StringOutput
+	// TopicName is the generated topic name.
+	TopicName pulumi.StringOutput
+}
+
+// NewTopic creates an SNS topic with the configured subscriptions and
+// publish policy.
+func NewTopic(ctx *pulumi.Context, name string, config *TopicConfig, opts ...pulumi.ResourceOption) (*Topic, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &Topic{}
+	err := ctx.RegisterComponentResource("denecloud:aws:Topic", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	var invokeOpts []pulumi.InvokeOption
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+		invokeOpts = append(invokeOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	resourceTags := tags.MergeTags(config.Environment, config.Tags)
+
+	topicArgs := &sns.TopicArgs{
+		Tags: resourceTags,
+	}
+	if config.DisplayName != "" {
+		topicArgs.DisplayName = pulumi.String(config.DisplayName)
+	}
+	if config.KmsMasterKeyId != "" {
+		topicArgs.KmsMasterKeyId = pulumi.String(config.KmsMasterKeyId)
+	} else if config.Encrypted {
+		topicArgs.KmsMasterKeyId = pulumi.String("alias/aws/sns")
+	}
+	topic, err := sns.NewTopic(ctx, name, topicArgs, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Topic = topic
+	comp.TopicArn = topic.Arn
+	comp.TopicName = topic.Name
+
+	for i, sub := range config.Subscriptions {
+		subArgs := &sns.TopicSubscriptionArgs{
+			Topic:    topic.Arn,
+			Protocol: pulumi.String(sub.Protocol),
+			Endpoint: pulumi.String(sub.Endpoint),
+		}
+		if sub.RawMessageDelivery {
+			subArgs.RawMessageDelivery = pulumi.Bool(true)
+		}
+		_, err := sns.NewTopicSubscription(ctx, fmt.Sprintf("%s-%s-%d", name, sub.Protocol, i), subArgs, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(config.AllowPublishServices) > 0 {
+		// Scope the grant to topics owned by this account so another
+		// account cannot confuse the service into publishing here.
+		caller, err := aws.GetCallerIdentity(ctx, nil, invokeOpts...)
+		if err != nil {
+			return nil, err
+		}
+		policy := topic.Arn.ApplyT(func(arn string) (string, error) {
+			return renderPublishPolicy(arn, caller.AccountId, config.AllowPublishServices)
+		}).(pulumi.StringOutput)
+		_, err = sns.NewTopicPolicy(ctx, name, &sns.TopicPolicyArgs{
+			Arn:    topic.Arn,
+			Policy: policy,
+		}, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ctx.RegisterResourceOutputs(comp, pulumi.Map{
+		"topicArn":  comp.TopicArn,
+		"topicName": comp.TopicName,
+	}); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
+
+// renderPublishPolicy builds a topic policy granting each service
+// principal sns:Publish on the topic, conditioned on the source
+// account.
+func renderPublishPolicy(topicArn, accountID string, services []string) (string, error) {
+	statements := make([]map[string]any, 0, len(services))
+	for _, service := range services {
+		statements = append(statements, map[string]any{
+			"Effect":    "Allow",
+			"Principal": map[string]any{"Service": service},
+			"Action":    "sns:Publish",
+			"Resource":  topicArn,
+			"Condition": map[string]any{
+				"StringEquals": map[string]any{"AWS:SourceAccount": accountID},
+			},
+		})
+	}
+	document, err := json.Marshal(map[string]any{
+		"Version":   "2012-10-17",
+		"Statement": statements,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(document), nil
+}
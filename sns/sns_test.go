@@ -0,0 +1,107 @@
+package sns
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const (
+	topicToken        = "aws:sns/topic:Topic"
+	subscriptionToken = "aws:sns/topicSubscription:TopicSubscription"
+	policyToken       = "aws:sns/topicPolicy:TopicPolicy"
+)
+
+func TestEmailSubscription(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Subscriptions = []SubscriptionConfig{
+			{Protocol: "email", Endpoint: "oncall@example.com"},
+		}
+		_, err := NewTopic(ctx, "alerts", config)
+		return err
+	})
+
+	subs := mocks.ByType(subscriptionToken)
+	if len(subs) != 1 {
+		t.Fatalf("expected 1 subscription, got %d", len(subs))
+	}
+	if got := subs[0].Inputs["protocol"].StringValue(); got != "email" {
+		t.Errorf("protocol = %s, want email", got)
+	}
+	if got := subs[0].Inputs["endpoint"].StringValue(); got != "oncall@example.com" {
+		t.Errorf("endpoint = %s, want oncall@example.com", got)
+	}
+}
+
+func TestCloudWatchPublishPolicy(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.AllowPublishServices = []string{"cloudwatch.amazonaws.com"}
+		_, err := NewTopic(ctx, "alerts", config)
+		return err
+	})
+
+	policies := mocks.ByType(policyToken)
+	if len(policies) != 1 {
+		t.Fatalf("expected 1 topic policy, got %d", len(policies))
+	}
+	var document struct {
+		Statement []struct {
+			Effect    string
+			Action    string
+			Principal struct {
+				Service string
+			}
+			Condition struct {
+				StringEquals map[string]string
+			}
+		}
+	}
+	raw := policies[0].Inputs["policy"].StringValue()
+	if err := json.Unmarshal([]byte(raw), &document); err != nil {
+		t.Fatalf("policy is not valid JSON: %v", err)
+	}
+	if len(document.Statement) != 1 {
+		t.Fatalf("expected 1 statement, got %d", len(document.Statement))
+	}
+	statement := document.Statement[0]
+	if statement.Principal.Service != "cloudwatch.amazonaws.com" {
+		t.Errorf("principal = %s, want cloudwatch.amazonaws.com", statement.Principal.Service)
+	}
+	if statement.Action != "sns:Publish" {
+		t.Errorf("action = %s, want sns:Publish", statement.Action)
+	}
+	if got := statement.Condition.StringEquals["AWS:SourceAccount"]; got != "123456789012" {
+		t.Errorf("source account = %s, want 123456789012", got)
+	}
+}
+
+func TestEncryptionDefaultsToManagedKey(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Encrypted = true
+		_, err := NewTopic(ctx, "alerts", config)
+		return err
+	})
+
+	topic := mocks.ByType(topicToken)[0]
+	if got := topic.Inputs["kmsMasterKeyId"].StringValue(); got != "alias/aws/sns" {
+		t.Errorf("kmsMasterKeyId = %s, want alias/aws/sns", got)
+	}
+}
+
+func TestInvalidSubscriptionRejected(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Subscriptions = []SubscriptionConfig{
+			{Protocol: "sqs", Endpoint: "not-an-arn"},
+		}
+		_, err := NewTopic(ctx, "alerts", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected an sqs subscription with a non-ARN endpoint to be rejected")
+	}
+}
@@ -0,0 +1,78 @@
+package sqs
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Option mutates a QueueConfig. Options are applied in order, so later
+// options override earlier ones.
+type Option func(*QueueConfig)
+
+// WithEnvironment sets the environment tag, e.g. "dev" or "prod".
+func WithEnvironment(environment string) Option {
+	return func(c *QueueConfig) { c.Environment = environment }
+}
+
+// WithQueueName overrides the generated queue name.
+func WithQueueName(queueName string) Option {
+	return func(c *QueueConfig) { c.QueueName = queueName }
+}
+
+// WithFifo makes this a FIFO queue, optionally deduplicating on the
+// message body.
+func WithFifo(contentBasedDeduplication bool) Option {
+	return func(c *QueueConfig) {
+		c.Fifo = true
+		c.ContentBasedDeduplication = contentBasedDeduplication
+	}
+}
+
+// WithVisibilityTimeout hides received messages for the given number
+// of seconds.
+func WithVisibilityTimeout(seconds int) Option {
+	return func(c *QueueConfig) { c.VisibilityTimeoutSeconds = seconds }
+}
+
+// WithMessageRetention keeps messages for the given number of seconds.
+func WithMessageRetention(seconds int) Option {
+	return func(c *QueueConfig) { c.MessageRetentionSeconds = seconds }
+}
+
+// WithDeadLetter provisions a dead-letter queue receiving messages
+// after maxReceiveCount failed receives.
+func WithDeadLetter(maxReceiveCount int) Option {
+	return func(c *QueueConfig) {
+		c.DeadLetter = &DeadLetterConfig{MaxReceiveCount: maxReceiveCount}
+	}
+}
+
+// WithEncryption enables server-side encryption with the SQS-managed
+// key.
+func WithEncryption() Option {
+	return func(c *QueueConfig) { c.Encrypted = true }
+}
+
+// WithKmsKey encrypts messages with a customer-managed KMS key.
+func WithKmsKey(keyID string) Option {
+	return func(c *QueueConfig) { c.KmsMasterKeyId = keyID }
+}
+
+// WithTags merges tags into the resource tag set.
+func WithTags(tags map[string]string) Option {
+	return func(c *QueueConfig) {
+		if c.Tags == nil {
+			c.Tags = map[string]string{}
+		}
+		for k, v := range tags {
+			c.Tags[k] = v
+		}
+	}
+}
+
+// NewQueueWithOptions builds a QueueConfig from functional options and
+// creates the queue. Equivalent to NewQueue with a hand-built config.
+func NewQueueWithOptions(ctx *pulumi.Context, name string, options ...Option) (*Queue, error) {
+	config := &QueueConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return NewQueue(ctx, name, config)
+}
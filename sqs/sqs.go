@@ -0,0 +1,220 @@
+// Package sqs provides a reusable Pulumi component for SQS queues with
+// dead-letter and encryption defaults.
+package sqs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/sqs"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// DeadLetterConfig provisions a companion dead-letter queue and the
+// redrive policy moving exhausted messages into it.
+type DeadLetterConfig struct {
+	// MaxReceiveCount is how often a message may be received before it
+	// moves to the dead-letter queue. Must be at least 1.
+	MaxReceiveCount int
+	// MessageRetentionSeconds is how long the dead-letter queue keeps
+	// messages. Defaults to 1209600 (14 days, the SQS maximum) so
+	// failures stay inspectable.
+	MessageRetentionSeconds int
+}
+
+// QueueConfig configures a Queue component.
+type QueueConfig struct {
+	// Environment tags every resource, e.g. "dev" or "prod".
+	Environment string
+	// QueueName overrides the generated queue name. FIFO queue names
+	// must end in ".fifo".
+	QueueName string
+	// Fifo makes this a FIFO queue with ordering and deduplication.
+	Fifo bool
+	// ContentBasedDeduplication derives the deduplication id from the
+	// message body. Requires Fifo.
+	ContentBasedDeduplication bool
+	// VisibilityTimeoutSeconds hides received messages for this long.
+	// Zero keeps the SQS default of 30 seconds.
+	VisibilityTimeoutSeconds int
+	// MessageRetentionSeconds is how long messages are kept, between 60
+	// and 1209600. Zero keeps the SQS default of 4 days.
+	MessageRetentionSeconds int
+	// DeadLetter creates a companion dead-letter queue with a redrive
+	// policy.
+	DeadLetter *DeadLetterConfig
+	// Encrypted enables server-side encryption with the SQS-managed
+	// key. Use KmsMasterKeyId for a customer-managed key.
+	Encrypted bool
+	// KmsMasterKeyId encrypts messages with a customer-managed KMS key.
+	// Implies Encrypted.
+	KmsMasterKeyId string
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// Validate checks the config for errors that would otherwise surface
+// as opaque AWS API failures. All violations are reported at once.
+func (c *QueueConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	if c.QueueName != "" {
+		if c.Fifo && !strings.HasSuffix(c.QueueName, ".fifo") {
+			errs = append(errs, fmt.Errorf("FIFO queue names must end in .fifo, got %q", c.QueueName))
+		}
+		if !c.Fifo && strings.HasSuffix(c.QueueName, ".fifo") {
+			errs = append(errs, fmt.Errorf("queue name %q ends in .fifo but Fifo is not set", c.QueueName))
+		}
+	}
+	if c.ContentBasedDeduplication && !c.Fifo {
+		errs = append(errs, fmt.Errorf("ContentBasedDeduplication requires a FIFO queue"))
+	}
+	if c.MessageRetentionSeconds != 0 && (c.MessageRetentionSeconds < 60 || c.MessageRetentionSeconds > 1209600) {
+		errs = append(errs, fmt.Errorf("invalid MessageRetentionSeconds %d: must be between 60 and 1209600", c.MessageRetentionSeconds))
+	}
+	if c.DeadLetter != nil && c.DeadLetter.MaxReceiveCount < 1 {
+		errs = append(errs, fmt.Errorf("DeadLetter.MaxReceiveCount must be at least 1, got %d", c.DeadLetter.MaxReceiveCount))
+	}
+	return errors.Join(errs...)
+}
+
+// Queue is an SQS queue with an optional companion dead-letter queue.
+// Pass QueueArn to Lambda event source mappings and QueueURL to
+// producers.
+type Queue struct {
+	pulumi.ResourceState
+
+	// Queue is the underlying SQS queue resource.
+	Queue *sqs.Queue
+	// QueueArn is the queue ARN.
+	QueueArn pulumi.StringOutput
+	// QueueURL is the queue URL producers send to.
+	QueueURL pulumi.StringOutput
+	// DeadLetterQueue is the companion dead-letter queue. Nil unless
+	// DeadLetter is configured.
+	DeadLetterQueue *sqs.Queue
+	// DeadLetterQueueArn is the dead-letter queue ARN. Unset unless
+	// DeadLetter is configured.
+	DeadLetterQueueArn pulumi.StringOutput
+}
+
+// NewQueue creates an SQS queue from the given config.
+func NewQueue(ctx *pulumi.Context, name string, config *QueueConfig, opts ...pulumi.ResourceOption) (*Queue, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &Queue{}
+	err := ctx.RegisterComponentResource("denecloud:aws:Queue", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	resourceTags := tags.MergeTags(config.Environment, config.Tags)
+
+	queueArgs := &sqs.QueueArgs{
+		Tags: resourceTags,
+	}
+	if config.Fifo {
+		// FIFO queue names must carry the .fifo suffix, which the
+		// autonamer does not add, so FIFO queues get explicit names.
+		queueName := config.QueueName
+		if queueName == "" {
+			queueName = name + ".fifo"
+		}
+		queueArgs.Name = pulumi.String(queueName)
+		queueArgs.FifoQueue = pulumi.Bool(true)
+		if config.ContentBasedDeduplication {
+			queueArgs.ContentBasedDeduplication = pulumi.Bool(true)
+		}
+	} else if config.QueueName != "" {
+		queueArgs.Name = pulumi.String(config.QueueName)
+	}
+	if config.VisibilityTimeoutSeconds > 0 {
+		queueArgs.VisibilityTimeoutSeconds = pulumi.Int(config.VisibilityTimeoutSeconds)
+	}
+	if config.MessageRetentionSeconds > 0 {
+		queueArgs.MessageRetentionSeconds = pulumi.Int(config.MessageRetentionSeconds)
+	}
+	if config.KmsMasterKeyId != "" {
+		queueArgs.KmsMasterKeyId = pulumi.String(config.KmsMasterKeyId)
+	} else if config.Encrypted {
+		queueArgs.SqsManagedSseEnabled = pulumi.Bool(true)
+	}
+
+	if config.DeadLetter != nil {
+		retention := config.DeadLetter.MessageRetentionSeconds
+		if retention == 0 {
+			retention = 1209600
+		}
+		dlqArgs := &sqs.QueueArgs{
+			MessageRetentionSeconds: pulumi.Int(retention),
+			Tags:                    resourceTags,
+		}
+		if config.Fifo {
+			// A FIFO queue's dead-letter queue must be FIFO as well.
+			dlqArgs.Name = pulumi.String(name + "-dlq.fifo")
+			dlqArgs.FifoQueue = pulumi.Bool(true)
+		}
+		if config.KmsMasterKeyId != "" {
+			dlqArgs.KmsMasterKeyId = pulumi.String(config.KmsMasterKeyId)
+		} else if config.Encrypted {
+			dlqArgs.SqsManagedSseEnabled = pulumi.Bool(true)
+		}
+		dlq, err := sqs.NewQueue(ctx, name+"-dlq", dlqArgs, parentOpts...)
+		if err != nil {
+			return nil, err
+		}
+		comp.DeadLetterQueue = dlq
+		comp.DeadLetterQueueArn = dlq.Arn
+
+		maxReceiveCount := config.DeadLetter.MaxReceiveCount
+		queueArgs.RedrivePolicy = dlq.Arn.ApplyT(func(arn string) (string, error) {
+			policy, err := json.Marshal(map[string]any{
+				"deadLetterTargetArn": arn,
+				"maxReceiveCount":     maxReceiveCount,
+			})
+			if err != nil {
+				return "", fmt.Errorf("marshaling redrive policy: %w", err)
+			}
+			return string(policy), nil
+		}).(pulumi.StringOutput)
+	}
+
+	queue, err := sqs.NewQueue(ctx, name, queueArgs, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.Queue = queue
+	comp.QueueArn = queue.Arn
+	comp.QueueURL = queue.Url
+
+	outputs := pulumi.Map{
+		"queueArn": comp.QueueArn,
+		"queueUrl": comp.QueueURL,
+	}
+	if comp.DeadLetterQueueArn != (pulumi.StringOutput{}) {
+		outputs["deadLetterQueueArn"] = comp.DeadLetterQueueArn
+	}
+	if err := ctx.RegisterResourceOutputs(comp, outputs); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
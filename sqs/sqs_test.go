@@ -0,0 +1,94 @@
+package sqs
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+const queueToken = "aws:sqs/queue:Queue"
+
+func TestDeadLetterRedrivePolicy(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.DeadLetter = &DeadLetterConfig{MaxReceiveCount: 5}
+		_, err := NewQueue(ctx, "jobs", config)
+		return err
+	})
+
+	queues := mocks.ByType(queueToken)
+	if len(queues) != 2 {
+		t.Fatalf("expected main and dead-letter queues, got %d", len(queues))
+	}
+	var main *pulumi.MockResourceArgs
+	for _, q := range queues {
+		if !strings.HasSuffix(q.Name, "-dlq") {
+			main = &q
+		}
+	}
+	if main == nil {
+		t.Fatal("no main queue registered")
+	}
+	var redrive struct {
+		DeadLetterTargetArn string `json:"deadLetterTargetArn"`
+		MaxReceiveCount     int    `json:"maxReceiveCount"`
+	}
+	raw := main.Inputs["redrivePolicy"].StringValue()
+	if err := json.Unmarshal([]byte(raw), &redrive); err != nil {
+		t.Fatalf("redrive policy is not valid JSON: %v", err)
+	}
+	if redrive.MaxReceiveCount != 5 {
+		t.Errorf("maxReceiveCount = %d, want 5", redrive.MaxReceiveCount)
+	}
+	if !strings.Contains(redrive.DeadLetterTargetArn, "jobs-dlq") {
+		t.Errorf("deadLetterTargetArn = %q, want the dead-letter queue ARN", redrive.DeadLetterTargetArn)
+	}
+}
+
+func TestFifoQueueNamedWithSuffix(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Fifo = true
+		config.ContentBasedDeduplication = true
+		_, err := NewQueue(ctx, "jobs", config)
+		return err
+	})
+
+	queue := mocks.ByType(queueToken)[0]
+	if got := queue.Inputs["name"].StringValue(); got != "jobs.fifo" {
+		t.Errorf("queue name = %s, want jobs.fifo", got)
+	}
+	if !queue.Inputs["fifoQueue"].BoolValue() {
+		t.Error("fifoQueue not set")
+	}
+	if !queue.Inputs["contentBasedDeduplication"].BoolValue() {
+		t.Error("contentBasedDeduplication not set")
+	}
+}
+
+func TestFifoNameWithoutSuffixRejected(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Fifo = true
+		config.QueueName = "jobs"
+		_, err := NewQueue(ctx, "jobs", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected a FIFO queue name without the .fifo suffix to be rejected")
+	}
+}
+
+func TestDeduplicationRequiresFifo(t *testing.T) {
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ContentBasedDeduplication = true
+		_, err := NewQueue(ctx, "jobs", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", &testMocks{}))
+	if err == nil {
+		t.Fatal("expected content-based deduplication without FIFO to be rejected")
+	}
+}
@@ -0,0 +1,62 @@
+// Package tags centralizes the resource tagging conventions shared by
+// every component: an Environment tag, a ManagedBy marker, optional
+// process-wide defaults and per-resource user tags.
+package tags
+
+import (
+	"sync"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+var (
+	mu       sync.RWMutex
+	defaults = map[string]string{}
+)
+
+// RegisterDefaults merges tags into the process-wide default tag set
+// applied by MergeTags. Typically called once at program start, e.g.
+// to stamp every resource with a team or cost-center tag.
+func RegisterDefaults(tags map[string]string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for k, v := range tags {
+		defaults[k] = v
+	}
+}
+
+// RegisterCostCenter registers a CostCenter default tag.
+func RegisterCostCenter(costCenter string) {
+	RegisterDefaults(map[string]string{"CostCenter": costCenter})
+}
+
+// RegisterOwner registers an Owner default tag.
+func RegisterOwner(owner string) {
+	RegisterDefaults(map[string]string{"Owner": owner})
+}
+
+// ClearDefaults removes all registered defaults. Mainly for tests.
+func ClearDefaults() {
+	mu.Lock()
+	defer mu.Unlock()
+	defaults = map[string]string{}
+}
+
+// MergeTags builds the tag map for a resource. Precedence, lowest to
+// highest: the Environment/ManagedBy base tags, defaults registered
+// via RegisterDefaults, then the caller's tags.
+func MergeTags(environment string, tags map[string]string) pulumi.StringMap {
+	merged := pulumi.StringMap{
+		"Environment": pulumi.String(environment),
+		"ManagedBy":   pulumi.String("pulumi"),
+	}
+	mu.RLock()
+	for k, v := range defaults {
+		merged[k] = pulumi.String(v)
+	}
+	mu.RUnlock()
+	for k, v := range tags {
+		merged[k] = pulumi.String(v)
+	}
+	return merged
+}
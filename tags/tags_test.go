@@ -0,0 +1,47 @@
+package tags
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+)
+
+func TestMergeTagsDefaults(t *testing.T) {
+	merged := MergeTags("dev", nil)
+	if merged["Environment"] != pulumi.String("dev") {
+		t.Errorf("Environment = %v, want dev", merged["Environment"])
+	}
+	if merged["ManagedBy"] != pulumi.String("pulumi") {
+		t.Errorf("ManagedBy = %v, want pulumi", merged["ManagedBy"])
+	}
+}
+
+func TestMergeTagsUserOverrides(t *testing.T) {
+	merged := MergeTags("dev", map[string]string{"ManagedBy": "terraform", "Team": "platform"})
+	if merged["ManagedBy"] != pulumi.String("terraform") {
+		t.Errorf("user tag did not override ManagedBy: %v", merged["ManagedBy"])
+	}
+	if merged["Team"] != pulumi.String("platform") {
+		t.Errorf("Team = %v, want platform", merged["Team"])
+	}
+}
+
+func TestRegisteredDefaultsPrecedence(t *testing.T) {
+	t.Cleanup(ClearDefaults)
+	RegisterCostCenter("cc-42")
+	RegisterOwner("data-eng")
+	RegisterDefaults(map[string]string{"Environment": "global"})
+
+	merged := MergeTags("dev", map[string]string{"Owner": "overridden"})
+	if merged["CostCenter"] != pulumi.String("cc-42") {
+		t.Errorf("CostCenter = %v, want cc-42", merged["CostCenter"])
+	}
+	// Registered defaults beat the base tags...
+	if merged["Environment"] != pulumi.String("global") {
+		t.Errorf("Environment = %v, want global", merged["Environment"])
+	}
+	// ...but user tags beat registered defaults.
+	if merged["Owner"] != pulumi.String("overridden") {
+		t.Errorf("Owner = %v, want overridden", merged["Owner"])
+	}
+}
@@ -0,0 +1,60 @@
+package waf
+
+import "github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+// Option mutates a WebACLConfig. Options are applied in order, so
+// later options override earlier ones.
+type Option func(*WebACLConfig)
+
+// WithEnvironment sets the environment tag, e.g. "dev" or "prod".
+func WithEnvironment(environment string) Option {
+	return func(c *WebACLConfig) { c.Environment = environment }
+}
+
+// WithScope sets the ACL scope, CLOUDFRONT or REGIONAL.
+func WithScope(scope string) Option {
+	return func(c *WebACLConfig) { c.Scope = scope }
+}
+
+// WithBlockByDefault denies requests that match no rule.
+func WithBlockByDefault() Option {
+	return func(c *WebACLConfig) { c.BlockByDefault = true }
+}
+
+// WithManagedRuleGroup appends an AWS-vendored managed rule group.
+func WithManagedRuleGroup(name string) Option {
+	return func(c *WebACLConfig) {
+		c.ManagedRuleGroups = append(c.ManagedRuleGroups, ManagedRuleGroupConfig{Name: name})
+	}
+}
+
+// WithRateLimit appends a rate-based rule blocking IPs that exceed
+// limit requests per five minutes.
+func WithRateLimit(name string, limit int) Option {
+	return func(c *WebACLConfig) {
+		c.RateLimitRules = append(c.RateLimitRules, RateLimitRuleConfig{Name: name, Limit: limit})
+	}
+}
+
+// WithTags merges tags into the resource tag set.
+func WithTags(tags map[string]string) Option {
+	return func(c *WebACLConfig) {
+		if c.Tags == nil {
+			c.Tags = map[string]string{}
+		}
+		for k, v := range tags {
+			c.Tags[k] = v
+		}
+	}
+}
+
+// NewWebACLWithOptions builds a WebACLConfig from functional options
+// and creates the web ACL. Equivalent to NewWebACL with a hand-built
+// config.
+func NewWebACLWithOptions(ctx *pulumi.Context, name string, options ...Option) (*WebACL, error) {
+	config := &WebACLConfig{}
+	for _, option := range options {
+		option(config)
+	}
+	return NewWebACL(ctx, name, config)
+}
@@ -0,0 +1,229 @@
+// Package waf provides a reusable Pulumi component for WAFv2 web ACLs
+// protecting CloudFront distributions and regional API endpoints.
+package waf
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws"
+	"github.com/pulumi/pulumi-aws/sdk/v6/go/aws/wafv2"
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/tags"
+)
+
+// Managed rule group names commonly attached to a web ACL. Any other
+// vendor-published group name is accepted as well.
+const (
+	RuleGroupCommon         = "AWSManagedRulesCommonRuleSet"
+	RuleGroupKnownBadInputs = "AWSManagedRulesKnownBadInputsRuleSet"
+)
+
+// ManagedRuleGroupConfig attaches a vendor-managed rule group to the
+// web ACL. Rule actions inside the group are kept as published.
+type ManagedRuleGroupConfig struct {
+	// Name is the rule group name, e.g. RuleGroupCommon.
+	Name string
+	// Vendor is the rule group vendor. Defaults to "AWS".
+	Vendor string
+}
+
+// RateLimitRuleConfig blocks IPs that exceed a request rate.
+type RateLimitRuleConfig struct {
+	// Name identifies the rule and its CloudWatch metric.
+	Name string
+	// Limit is the maximum number of requests allowed from a single IP
+	// in a five-minute window. Must be at least 100.
+	Limit int
+}
+
+// WebACLConfig configures a WebACL component.
+type WebACLConfig struct {
+	// Environment tags every resource, e.g. "dev" or "prod".
+	Environment string
+	// Scope is CLOUDFRONT or REGIONAL. Defaults to REGIONAL.
+	// CLOUDFRONT-scope ACLs must be created in us-east-1.
+	Scope string
+	// BlockByDefault denies requests that match no rule. The default
+	// allows them, with rules blocking the unwanted traffic.
+	BlockByDefault bool
+	// ManagedRuleGroups are evaluated before the rate limit rules, in
+	// the order given.
+	ManagedRuleGroups []ManagedRuleGroupConfig
+	// RateLimitRules are evaluated after the managed rule groups.
+	RateLimitRules []RateLimitRuleConfig
+	// Tags are merged with the default tags on every resource.
+	Tags map[string]string
+}
+
+// Validate checks the config for errors that would otherwise surface
+// as opaque AWS API failures. All violations are reported at once.
+func (c *WebACLConfig) Validate() error {
+	var errs []error
+	if c.Environment == "" {
+		errs = append(errs, fmt.Errorf("Environment is required"))
+	}
+	switch c.Scope {
+	case "", "REGIONAL", "CLOUDFRONT":
+	default:
+		errs = append(errs, fmt.Errorf("invalid Scope %q: must be CLOUDFRONT or REGIONAL", c.Scope))
+	}
+	for i, group := range c.ManagedRuleGroups {
+		if group.Name == "" {
+			errs = append(errs, fmt.Errorf("ManagedRuleGroups[%d]: Name is required", i))
+		}
+	}
+	for i, rule := range c.RateLimitRules {
+		if rule.Name == "" {
+			errs = append(errs, fmt.Errorf("RateLimitRules[%d]: Name is required", i))
+		}
+		if rule.Limit < 100 {
+			errs = append(errs, fmt.Errorf("RateLimitRules[%d]: Limit must be at least 100, got %d", i, rule.Limit))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WebACL is a WAFv2 web ACL composed of managed rule groups and
+// rate-based rules. Pass ACLArn to a CloudFront distribution or a
+// regional resource association depending on the scope.
+type WebACL struct {
+	pulumi.ResourceState
+
+	// ACL is the underlying web ACL resource.
+	ACL *wafv2.WebAcl
+	// ACLArn is the web ACL ARN.
+	ACLArn pulumi.StringOutput
+	// ACLID is the web ACL id.
+	ACLID pulumi.StringOutput
+}
+
+// NewWebACL creates a WAFv2 web ACL with the configured rules.
+func NewWebACL(ctx *pulumi.Context, name string, config *WebACLConfig, opts ...pulumi.ResourceOption) (*WebACL, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	comp := &WebACL{}
+	err := ctx.RegisterComponentResource("denecloud:aws:WebACL", name, comp, opts...)
+	if err != nil {
+		return nil, err
+	}
+	parentOpts := []pulumi.ResourceOption{pulumi.Parent(comp)}
+	// Child resources only inherit Parent automatically; thread any
+	// explicit provider through so they target the same account/region
+	// as the component itself.
+	resolved, err := pulumi.NewResourceOptions(opts...)
+	if err != nil {
+		return nil, err
+	}
+	var invokeOpts []pulumi.InvokeOption
+	if resolved.Provider != nil {
+		parentOpts = append(parentOpts, pulumi.Provider(resolved.Provider))
+		invokeOpts = append(invokeOpts, pulumi.Provider(resolved.Provider))
+	}
+
+	scope := config.Scope
+	if scope == "" {
+		scope = "REGIONAL"
+	}
+	if scope == "CLOUDFRONT" {
+		// CloudFront-scope ACLs only exist in us-east-1; catch a
+		// mismatched provider region before AWS rejects the call.
+		region, err := aws.GetRegion(ctx, nil, invokeOpts...)
+		if err != nil {
+			return nil, err
+		}
+		if region.Name != "us-east-1" {
+			return nil, fmt.Errorf("CLOUDFRONT scope web ACLs must be created in us-east-1, not %s", region.Name)
+		}
+	}
+
+	resourceTags := tags.MergeTags(config.Environment, config.Tags)
+
+	defaultAction := &wafv2.WebAclDefaultActionArgs{
+		Allow: &wafv2.WebAclDefaultActionAllowArgs{},
+	}
+	if config.BlockByDefault {
+		defaultAction = &wafv2.WebAclDefaultActionArgs{
+			Block: &wafv2.WebAclDefaultActionBlockArgs{},
+		}
+	}
+
+	// Priorities follow declaration order: managed rule groups first,
+	// then the rate limit rules.
+	rules := wafv2.WebAclRuleArray{}
+	for _, group := range config.ManagedRuleGroups {
+		vendor := group.Vendor
+		if vendor == "" {
+			vendor = "AWS"
+		}
+		rules = append(rules, &wafv2.WebAclRuleArgs{
+			Name:     pulumi.String(group.Name),
+			Priority: pulumi.Int(len(rules)),
+			OverrideAction: &wafv2.WebAclRuleOverrideActionArgs{
+				None: &wafv2.WebAclRuleOverrideActionNoneArgs{},
+			},
+			Statement: &wafv2.WebAclRuleStatementArgs{
+				ManagedRuleGroupStatement: &wafv2.WebAclRuleStatementManagedRuleGroupStatementArgs{
+					Name:       pulumi.String(group.Name),
+					VendorName: pulumi.String(vendor),
+				},
+			},
+			VisibilityConfig: visibilityConfig(group.Name),
+		})
+	}
+	for _, rule := range config.RateLimitRules {
+		rules = append(rules, &wafv2.WebAclRuleArgs{
+			Name:     pulumi.String(rule.Name),
+			Priority: pulumi.Int(len(rules)),
+			Action: &wafv2.WebAclRuleActionArgs{
+				Block: &wafv2.WebAclRuleActionBlockArgs{},
+			},
+			Statement: &wafv2.WebAclRuleStatementArgs{
+				RateBasedStatement: &wafv2.WebAclRuleStatementRateBasedStatementArgs{
+					AggregateKeyType: pulumi.String("IP"),
+					Limit:            pulumi.Int(rule.Limit),
+				},
+			},
+			VisibilityConfig: visibilityConfig(rule.Name),
+		})
+	}
+
+	acl, err := wafv2.NewWebAcl(ctx, name, &wafv2.WebAclArgs{
+		Scope:         pulumi.String(scope),
+		DefaultAction: defaultAction,
+		Rules:         rules,
+		VisibilityConfig: &wafv2.WebAclVisibilityConfigArgs{
+			CloudwatchMetricsEnabled: pulumi.Bool(true),
+			MetricName:               pulumi.String(name),
+			SampledRequestsEnabled:   pulumi.Bool(true),
+		},
+		Tags: resourceTags,
+	}, parentOpts...)
+	if err != nil {
+		return nil, err
+	}
+	comp.ACL = acl
+	comp.ACLArn = acl.Arn
+	comp.ACLID = acl.ID().ToStringOutput()
+
+	if err := ctx.RegisterResourceOutputs(comp, pulumi.Map{
+		"aclArn": comp.ACLArn,
+		"aclId":  comp.ACLID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return comp, nil
+}
+
+// visibilityConfig enables CloudWatch metrics and request sampling
+// under the given metric name.
+func visibilityConfig(metricName string) *wafv2.WebAclRuleVisibilityConfigArgs {
+	return &wafv2.WebAclRuleVisibilityConfigArgs{
+		CloudwatchMetricsEnabled: pulumi.Bool(true),
+		MetricName:               pulumi.String(metricName),
+		SampledRequestsEnabled:   pulumi.Bool(true),
+	}
+}
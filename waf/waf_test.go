@@ -0,0 +1,94 @@
+package waf
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v3/go/pulumi"
+
+	"github.com/denecloud/pulumi-constructs/internal/testmocks"
+)
+
+const webACLToken = "aws:wafv2/webAcl:WebAcl"
+
+func TestManagedRuleGroupRules(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.ManagedRuleGroups = []ManagedRuleGroupConfig{
+			{Name: RuleGroupCommon},
+			{Name: RuleGroupKnownBadInputs},
+		}
+		_, err := NewWebACL(ctx, "edge", config)
+		return err
+	})
+
+	acl := mocks.ByType(webACLToken)[0]
+	rules := acl.Inputs["rules"].ArrayValue()
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	first := rules[0].ObjectValue()
+	statement := first["statement"].ObjectValue()["managedRuleGroupStatement"].ObjectValue()
+	if got := statement["name"].StringValue(); got != RuleGroupCommon {
+		t.Errorf("rule 0 group name = %s, want %s", got, RuleGroupCommon)
+	}
+	if got := statement["vendorName"].StringValue(); got != "AWS" {
+		t.Errorf("rule 0 vendor = %s, want AWS", got)
+	}
+	if _, ok := first["overrideAction"].ObjectValue()["none"]; !ok {
+		t.Error("managed rule group should not override the group's actions")
+	}
+	if got := rules[1].ObjectValue()["priority"].NumberValue(); got != 1 {
+		t.Errorf("rule 1 priority = %v, want 1", got)
+	}
+}
+
+func TestRateBasedRule(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.RateLimitRules = []RateLimitRuleConfig{
+			{Name: "throttle-scrapers", Limit: 2000},
+		}
+		_, err := NewWebACL(ctx, "edge", config)
+		return err
+	})
+
+	acl := mocks.ByType(webACLToken)[0]
+	rule := acl.Inputs["rules"].ArrayValue()[0].ObjectValue()
+	statement := rule["statement"].ObjectValue()["rateBasedStatement"].ObjectValue()
+	if got := statement["limit"].NumberValue(); got != 2000 {
+		t.Errorf("rate limit = %v, want 2000", got)
+	}
+	if got := statement["aggregateKeyType"].StringValue(); got != "IP" {
+		t.Errorf("aggregateKeyType = %s, want IP", got)
+	}
+	if _, ok := rule["action"].ObjectValue()["block"]; !ok {
+		t.Error("rate-based rule should block matching requests")
+	}
+}
+
+func TestCloudFrontScopeRequiresUsEast1(t *testing.T) {
+	mocks := &testMocks{Recorder: testmocks.Recorder{Region: "eu-west-1"}}
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Scope = "CLOUDFRONT"
+		_, err := NewWebACL(ctx, "edge", config)
+		return err
+	}, pulumi.WithMocks("project", "stack", mocks))
+	if err == nil {
+		t.Fatal("expected a CLOUDFRONT scope ACL outside us-east-1 to be rejected")
+	}
+}
+
+func TestCloudFrontScopeAllowedInUsEast1(t *testing.T) {
+	mocks := runTest(t, func(ctx *pulumi.Context) error {
+		config := testConfig()
+		config.Scope = "CLOUDFRONT"
+		_, err := NewWebACL(ctx, "edge", config)
+		return err
+	})
+
+	acl := mocks.ByType(webACLToken)[0]
+	if got := acl.Inputs["scope"].StringValue(); got != "CLOUDFRONT" {
+		t.Errorf("scope = %s, want CLOUDFRONT", got)
+	}
+}